@@ -14,7 +14,6 @@ resource "keycloak_realm" "master_realm" {
   verify_email = false
   reset_password_allowed = false
   edit_username_allowed = false
-  brute_force_protected = false
 
 }
 
@@ -4,11 +4,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
+	neturl "net/url"
+	"strconv"
 )
 
+// Number of results requested at once from list endpoints supporting pagination
+const pageSize = 100
+
 // An authenticated Keycloak API client
 type KeycloakClient struct {
 	token string
@@ -54,6 +61,39 @@ func (c *KeycloakClient) get(url string, v interface{}) error {
 	return nil
 }
 
+// Performs GET requests for the pages of a list endpoint supporting the `first` and `max` parameters, until max
+// results were fetched or a page isn't full. Each page is handed to appendPage, which decodes it and returns the
+// number of results in it.
+func (c *KeycloakClient) getPages(url string, query neturl.Values, max int, appendPage func(body []byte) (int, error)) error {
+	for first := 0; first < max; {
+		size := pageSize
+		if max-first < size {
+			size = max - first
+		}
+
+		query.Set("first", strconv.Itoa(first))
+		query.Set("max", strconv.Itoa(size))
+
+		var body []byte
+		err := c.getRaw(url+"?"+query.Encode(), &body)
+		if err != nil {
+			return err
+		}
+
+		count, err := appendPage(body)
+		if err != nil {
+			return err
+		}
+
+		if count < size {
+			break
+		}
+		first += count
+	}
+
+	return nil
+}
+
 func (c *KeycloakClient) getRaw(url string, body *[]byte) error {
 	req, _ := http.NewRequest("GET", url, nil)
 	resp, err := c.do(req)
@@ -96,6 +136,96 @@ func (c *KeycloakClient) post(url string, v interface{}) (string, error) {
 	return resp.Header.Get("Location"), nil
 }
 
+// Attempts to POST to Keycloak and decodes the response body into result (if given).
+// Unlike post this is meant for actions rather than creating resources, so no location is returned.
+func (c *KeycloakClient) postForResult(url string, v interface{}, result interface{}) error {
+	body, err := c.postForRaw(url, v)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// Attempts to POST to Keycloak and returns the undecoded response body.
+func (c *KeycloakClient) postForRaw(url string, v interface{}) ([]byte, error) {
+	var reqBody io.Reader
+	if v != nil {
+		b, _ := json.Marshal(v)
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	req, err := http.NewRequest("POST", url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	if v != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return c.doForRaw(req)
+}
+
+// Attempts to POST a multipart form (e.g. a file upload) to Keycloak and decodes the response body into result.
+func (c *KeycloakClient) postMultipart(url string, fields map[string]string, file []byte, result interface{}) error {
+	var reqBody bytes.Buffer
+	w := multipart.NewWriter(&reqBody)
+
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+
+	if file != nil {
+		part, err := w.CreateFormFile("file", "file")
+		if err != nil {
+			return err
+		}
+		part.Write(file)
+	}
+	w.Close()
+
+	req, err := http.NewRequest("POST", url, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	body, err := c.doForRaw(req)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+func (c *KeycloakClient) doForRaw(req *http.Request) ([]byte, error) {
+	resp, err := c.do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 && resp.StatusCode != 204 {
+		return nil, fmt.Errorf("Could not %s %s: %s (%d)", req.Method, req.URL.String(), string(body), resp.StatusCode)
+	}
+
+	return body, nil
+}
+
 func (c *KeycloakClient) put(url string, v interface{}) error {
 	reqBody, _ := json.Marshal(v)
 	req, _ := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
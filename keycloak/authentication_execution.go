@@ -0,0 +1,120 @@
+package keycloak
+
+import (
+	"fmt"
+	neturl "net/url"
+)
+
+// Execution of a flow as listed by Keycloak. Subflows are executions too, with AuthenticationFlow set and FlowId
+// pointing to the subflow. The list of a flow includes the executions of its subflows, with a higher Level.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_authenticationexecutioninforepresentation
+type AuthenticationExecutionInfo struct {
+	Id                   string   `json:"id"`
+	Requirement          string   `json:"requirement"`
+	DisplayName          string   `json:"displayName,omitempty"`
+	Alias                string   `json:"alias,omitempty"`
+	Description          string   `json:"description,omitempty"`
+	RequirementChoices   []string `json:"requirementChoices,omitempty"`
+	Configurable         bool     `json:"configurable"`
+	AuthenticationFlow   bool     `json:"authenticationFlow"`
+	ProviderId           string   `json:"providerId,omitempty"`
+	AuthenticationConfig string   `json:"authenticationConfig,omitempty"`
+	FlowId               string   `json:"flowId,omitempty"`
+	Level                int      `json:"level"`
+	Index                int      `json:"index"`
+}
+
+const (
+	authenticationExecutionsUri      = "%s/auth/admin/realms/%s/authentication/flows/%s/executions"
+	authenticationExecutionCreateUri = "%s/auth/admin/realms/%s/authentication/flows/%s/executions/execution"
+	authenticationExecutionUri       = "%s/auth/admin/realms/%s/authentication/executions/%s"
+	authenticationExecutionRaiseUri  = "%s/auth/admin/realms/%s/authentication/executions/%s/raise-priority"
+	authenticationExecutionLowerUri  = "%s/auth/admin/realms/%s/authentication/executions/%s/lower-priority"
+)
+
+// Lists the executions of the flow with the given alias (and of its subflows), in the order they are executed.
+func (c *KeycloakClient) ListAuthenticationExecutions(flowAlias, realm string) ([]*AuthenticationExecutionInfo, error) {
+	url := fmt.Sprintf(authenticationExecutionsUri, c.url, realm, neturl.PathEscape(flowAlias))
+
+	var executions []*AuthenticationExecutionInfo
+	err := c.get(url, &executions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return executions, nil
+}
+
+// There is no API returning a single execution with its requirement, so it is looked up in the list of its flow.
+func (c *KeycloakClient) GetAuthenticationExecutionInfo(id, flowAlias, realm string) (*AuthenticationExecutionInfo, error) {
+	executions, err := c.ListAuthenticationExecutions(flowAlias, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, execution := range executions {
+		if execution.Id == id {
+			return execution, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Could not find execution %s in flow %s", id, flowAlias)
+}
+
+// Adds an execution of the given authenticator (e.g. `auth-otp-form`) to the end of the flow and returns its ID.
+func (c *KeycloakClient) CreateAuthenticationExecution(authenticator, flowAlias, realm string) (string, error) {
+	before, err := c.ListAuthenticationExecutions(flowAlias, realm)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf(authenticationExecutionCreateUri, c.url, realm, neturl.PathEscape(flowAlias))
+	_, err = c.post(url, map[string]string{"provider": authenticator})
+	if err != nil {
+		return "", err
+	}
+
+	// Older Keycloak versions don't return a location, so the new execution is the one that wasn't there before
+	existing := map[string]bool{}
+	for _, execution := range before {
+		existing[execution.Id] = true
+	}
+
+	after, err := c.ListAuthenticationExecutions(flowAlias, realm)
+	if err != nil {
+		return "", err
+	}
+
+	for _, execution := range after {
+		if !existing[execution.Id] && execution.Level == 0 && execution.ProviderId == authenticator {
+			return execution.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("Could not find the %s execution in flow %s after creating it", authenticator, flowAlias)
+}
+
+// Updates the requirement of an execution (or subflow), which is the only setting Keycloak allows changing.
+func (c *KeycloakClient) UpdateAuthenticationExecution(execution *AuthenticationExecutionInfo, flowAlias, realm string) error {
+	url := fmt.Sprintf(authenticationExecutionsUri, c.url, realm, neturl.PathEscape(flowAlias))
+	return c.put(url, *execution)
+}
+
+// Deletes an execution, deleting the execution of a subflow also deletes the subflow.
+func (c *KeycloakClient) DeleteAuthenticationExecution(id, realm string) error {
+	url := fmt.Sprintf(authenticationExecutionUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
+
+// Moves the execution up by one position within its flow.
+func (c *KeycloakClient) RaiseAuthenticationExecutionPriority(id, realm string) error {
+	url := fmt.Sprintf(authenticationExecutionRaiseUri, c.url, realm, id)
+	return c.postForResult(url, nil, nil)
+}
+
+// Moves the execution down by one position within its flow.
+func (c *KeycloakClient) LowerAuthenticationExecutionPriority(id, realm string) error {
+	url := fmt.Sprintf(authenticationExecutionLowerUri, c.url, realm, id)
+	return c.postForResult(url, nil, nil)
+}
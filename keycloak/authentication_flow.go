@@ -0,0 +1,113 @@
+package keycloak
+
+import (
+	"fmt"
+	neturl "net/url"
+)
+
+// Authentication flow resource as documented in the Keycloak REST API docs. The flow's executions are managed through
+// the executions API instead.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_authenticationflowrepresentation
+type AuthenticationFlow struct {
+	Id          string `json:"id,omitempty"`
+	Alias       string `json:"alias"`
+	Description string `json:"description"`
+	ProviderId  string `json:"providerId"`
+	TopLevel    bool   `json:"topLevel"`
+	BuiltIn     bool   `json:"builtIn"`
+}
+
+// The representation Keycloak expects when adding a subflow to a flow
+type authenticationSubflowCreate struct {
+	Alias       string `json:"alias"`
+	Type        string `json:"type"`
+	Provider    string `json:"provider,omitempty"`
+	Description string `json:"description"`
+}
+
+const (
+	authenticationFlowsUri    = "%s/auth/admin/realms/%s/authentication/flows"
+	authenticationFlowUri     = "%s/auth/admin/realms/%s/authentication/flows/%s"
+	authenticationSubflowsUri = "%s/auth/admin/realms/%s/authentication/flows/%s/executions/flow"
+)
+
+func (c *KeycloakClient) GetAuthenticationFlow(id, realm string) (*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, id)
+
+	var flow AuthenticationFlow
+	err := c.get(url, &flow)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &flow, nil
+}
+
+// Only lists the top level flows.
+func (c *KeycloakClient) ListAuthenticationFlows(realm string) ([]*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationFlowsUri, c.url, realm)
+
+	var flows []*AuthenticationFlow
+	err := c.get(url, &flows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return flows, nil
+}
+
+func (c *KeycloakClient) CreateAuthenticationFlow(flow *AuthenticationFlow, realm string) (*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationFlowsUri, c.url, realm)
+
+	flowLocation, err := c.post(url, *flow)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdFlow AuthenticationFlow
+	err = c.get(flowLocation, &createdFlow)
+
+	return &createdFlow, err
+}
+
+// Adds a subflow to the flow with the given alias. The subflow's type is `basic-flow` or `form-flow`, forms also need
+// the form provider (e.g. `registration-page-form`).
+func (c *KeycloakClient) CreateAuthenticationSubflow(subflow *AuthenticationFlow, provider, parentFlowAlias, realm string) (*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationSubflowsUri, c.url, realm, neturl.PathEscape(parentFlowAlias))
+
+	_, err := c.post(url, authenticationSubflowCreate{
+		Alias:       subflow.Alias,
+		Type:        subflow.ProviderId,
+		Provider:    provider,
+		Description: subflow.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Older Keycloak versions don't return a location, so the subflow is looked up by its alias instead
+	executions, err := c.ListAuthenticationExecutions(parentFlowAlias, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, execution := range executions {
+		if execution.AuthenticationFlow && execution.Level == 0 && execution.DisplayName == subflow.Alias {
+			return c.GetAuthenticationFlow(execution.FlowId, realm)
+		}
+	}
+
+	return nil, fmt.Errorf("Could not find subflow %s in flow %s after creating it", subflow.Alias, parentFlowAlias)
+}
+
+func (c *KeycloakClient) UpdateAuthenticationFlow(flow *AuthenticationFlow, realm string) error {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, flow.Id)
+	return c.put(url, *flow)
+}
+
+func (c *KeycloakClient) DeleteAuthenticationFlow(id, realm string) error {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
@@ -0,0 +1,81 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Config of an authentication execution, e.g. the settings of a conditional OTP execution.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_authenticatorconfigrepresentation
+type AuthenticatorConfig struct {
+	Id     string            `json:"id,omitempty"`
+	Alias  string            `json:"alias"`
+	Config map[string]string `json:"config"`
+}
+
+// Execution as stored by Keycloak, which (unlike the listed AuthenticationExecutionInfo) references its config by ID.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_authenticationexecutionrepresentation
+type AuthenticationExecution struct {
+	Id                  string `json:"id"`
+	Authenticator       string `json:"authenticator,omitempty"`
+	AuthenticatorConfig string `json:"authenticatorConfig,omitempty"`
+	FlowId              string `json:"flowId,omitempty"`
+	ParentFlow          string `json:"parentFlow,omitempty"`
+	Requirement         string `json:"requirement"`
+	Priority            int    `json:"priority"`
+}
+
+const (
+	authenticationExecutionConfigUri = "%s/auth/admin/realms/%s/authentication/executions/%s/config"
+	authenticatorConfigUri           = "%s/auth/admin/realms/%s/authentication/config/%s"
+)
+
+func (c *KeycloakClient) GetAuthenticationExecution(id, realm string) (*AuthenticationExecution, error) {
+	url := fmt.Sprintf(authenticationExecutionUri, c.url, realm, id)
+
+	var execution AuthenticationExecution
+	err := c.get(url, &execution)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &execution, nil
+}
+
+func (c *KeycloakClient) GetAuthenticatorConfig(id, realm string) (*AuthenticatorConfig, error) {
+	url := fmt.Sprintf(authenticatorConfigUri, c.url, realm, id)
+
+	var config AuthenticatorConfig
+	err := c.get(url, &config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// Creates the config of an execution, an execution can only have a single config.
+func (c *KeycloakClient) CreateAuthenticatorConfig(config *AuthenticatorConfig, executionId, realm string) (*AuthenticatorConfig, error) {
+	url := fmt.Sprintf(authenticationExecutionConfigUri, c.url, realm, executionId)
+
+	configLocation, err := c.post(url, *config)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdConfig AuthenticatorConfig
+	err = c.get(configLocation, &createdConfig)
+
+	return &createdConfig, err
+}
+
+func (c *KeycloakClient) UpdateAuthenticatorConfig(config *AuthenticatorConfig, realm string) error {
+	url := fmt.Sprintf(authenticatorConfigUri, c.url, realm, config.Id)
+	return c.put(url, *config)
+}
+
+func (c *KeycloakClient) DeleteAuthenticatorConfig(id, realm string) error {
+	url := fmt.Sprintf(authenticatorConfigUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
@@ -9,24 +9,28 @@ import (
 // http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_clientrepresentation
 
 type Client struct {
-	Id                        string                 `json:"id,omitempty"`
-	ClientId                  string                 `json:"clientId"`
-	Enabled                   bool                   `json:"enabled"`
-	ClientAuthenticatorType   string                 `json:"clientAuthenticatorType,omitempty"`
-	RedirectUris              []string               `json:"redirectUris"`
-	RootUrl                   string                 `json:"rootUrl"`
-	AdminUrl                  string                 `json:"adminUrl"`
-	BaseUrl                   string                 `json:"baseUrl"`
-	Protocol                  string                 `json:"protocol,omitempty"`
-	PublicClient              bool                   `json:"publicClient"`
-	BearerOnly                bool                   `json:"bearerOnly"`
-	ServiceAccountsEnabled    bool                   `json:"serviceAccountsEnabled"`
-	DirectAccessGrantsEnabled bool                   `json:"directAccessGrantsEnabled"`
-	ImplicitFlowEnabled       bool                   `json:"implicitFlowEnabled"`
-	StandardFlowEnabled       bool                   `json:"standardFlowEnabled"`
-	WebOrigins                []string               `json:"webOrigins"`
-	FullScopeAllowed          bool                   `json:"fullScopeAllowed"`
-	Attributes                map[string]interface{} `json:"attributes,omitempty"`
+	Id                                 string                 `json:"id,omitempty"`
+	ClientId                           string                 `json:"clientId"`
+	Enabled                            bool                   `json:"enabled"`
+	ClientAuthenticatorType            string                 `json:"clientAuthenticatorType,omitempty"`
+	Secret                             string                 `json:"secret,omitempty"`
+	RedirectUris                       []string               `json:"redirectUris"`
+	RootUrl                            string                 `json:"rootUrl"`
+	AdminUrl                           string                 `json:"adminUrl"`
+	BaseUrl                            string                 `json:"baseUrl"`
+	Protocol                           string                 `json:"protocol,omitempty"`
+	PublicClient                       bool                   `json:"publicClient"`
+	BearerOnly                         bool                   `json:"bearerOnly"`
+	ServiceAccountsEnabled             bool                   `json:"serviceAccountsEnabled"`
+	DirectAccessGrantsEnabled          bool                   `json:"directAccessGrantsEnabled"`
+	ImplicitFlowEnabled                bool                   `json:"implicitFlowEnabled"`
+	StandardFlowEnabled                bool                   `json:"standardFlowEnabled"`
+	WebOrigins                         []string               `json:"webOrigins"`
+	FullScopeAllowed                   bool                   `json:"fullScopeAllowed"`
+	ConsentRequired                    *bool                  `json:"consentRequired,omitempty"`
+	FrontchannelLogout                 *bool                  `json:"frontchannelLogout,omitempty"`
+	Attributes                         map[string]interface{} `json:"attributes,omitempty"`
+	AuthenticationFlowBindingOverrides map[string]string      `json:"authenticationFlowBindingOverrides,omitempty"`
 }
 
 type ClientSecret struct {
@@ -35,11 +39,11 @@ type ClientSecret struct {
 }
 
 const (
-	clientUri         = "%s/auth/admin/realms/%s/clients/%s"
-	clientList        = "%s/auth/admin/realms/%s/clients"
-	clientSecretUri   = "%s/auth/admin/realms/%s/clients/%s/client-secret"
-	clientUserUri     = "%s/auth/admin/realms/%s/clients/%s/service-account-user"
-	clientSamlDescUri = "%s/auth/admin/realms/%s/clients/%s/installation/providers/saml-idp-descriptor"
+	clientUri        = "%s/auth/admin/realms/%s/clients/%s"
+	clientList       = "%s/auth/admin/realms/%s/clients"
+	clientSecretUri  = "%s/auth/admin/realms/%s/clients/%s/client-secret"
+	clientUserUri    = "%s/auth/admin/realms/%s/clients/%s/service-account-user"
+	clientInstallUri = "%s/auth/admin/realms/%s/clients/%s/installation/providers/%s"
 )
 
 func (c *KeycloakClient) GetClient(id string, realm string) (*Client, error) {
@@ -68,6 +72,21 @@ func (c *KeycloakClient) GetClientSecret(id string, realm string) (*ClientSecret
 	return &secret, nil
 }
 
+// Generates a new secret for the client, the previous secret stops working immediately unless the realm has a client
+// secret rotation policy.
+func (c *KeycloakClient) RegenerateClientSecret(id string, realm string) (*ClientSecret, error) {
+	url := fmt.Sprintf(clientSecretUri, c.url, realm, id)
+
+	var secret ClientSecret
+	err := c.postForResult(url, nil, &secret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
 func (c *KeycloakClient) ListClients(realm string) ([]*Client, error) {
 	url := fmt.Sprintf(clientList, c.url, realm)
 
@@ -137,8 +156,10 @@ func (c *KeycloakClient) GetClientServiceAccountUser(id, realm string) (*User, e
 	return &user, nil
 }
 
-func (c *KeycloakClient) GetClientInstallationSamlDesc(id, realm string) (string, error) {
-	url := fmt.Sprintf(clientSamlDescUri, c.url, realm, id)
+// Returns the client configuration in the format of the given installation provider, e.g. `keycloak-oidc-keycloak-json`
+// or `saml-idp-descriptor`. Depending on the provider this is JSON, XML or plain text.
+func (c *KeycloakClient) GetClientInstallation(id, realm, providerId string) (string, error) {
+	url := fmt.Sprintf(clientInstallUri, c.url, realm, id, providerId)
 
 	var installation []byte
 	err := c.getRaw(url, &installation)
@@ -149,3 +170,7 @@ func (c *KeycloakClient) GetClientInstallationSamlDesc(id, realm string) (string
 
 	return string(installation), nil
 }
+
+func (c *KeycloakClient) GetClientInstallationSamlDesc(id, realm string) (string, error) {
+	return c.GetClientInstallation(id, realm, "saml-idp-descriptor")
+}
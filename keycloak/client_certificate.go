@@ -0,0 +1,99 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Keys and certificates stored in the attributes of a client, e.g. `jwt.credential` or `saml.signing`.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_certificaterepresentation
+type ClientCertificate struct {
+	PrivateKey  string `json:"privateKey,omitempty"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	Kid         string `json:"kid,omitempty"`
+}
+
+// Not a real object in keycloak, just the form fields expected by the upload endpoints.
+// Format is one of "JKS", "PKCS12", "Certificate PEM", "Public Key PEM" or "JSON Web Key Set".
+type ClientCertificateUpload struct {
+	Format        string
+	KeyAlias      string
+	KeyPassword   string
+	StorePassword string
+	File          []byte
+}
+
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_keystoreconfig
+type KeyStoreConfig struct {
+	RealmCertificate *bool  `json:"realmCertificate,omitempty"`
+	StorePassword    string `json:"storePassword,omitempty"`
+	KeyPassword      string `json:"keyPassword,omitempty"`
+	KeyAlias         string `json:"keyAlias,omitempty"`
+	RealmAlias       string `json:"realmAlias,omitempty"`
+	Format           string `json:"format,omitempty"`
+}
+
+const (
+	clientCertificateUri         = "%s/auth/admin/realms/%s/clients/%s/certificates/%s"
+	clientCertificateGenerateUri = "%s/auth/admin/realms/%s/clients/%s/certificates/%s/generate"
+	clientCertificateUploadUri   = "%s/auth/admin/realms/%s/clients/%s/certificates/%s/upload"
+	clientCertificateUploadCert  = "%s/auth/admin/realms/%s/clients/%s/certificates/%s/upload-certificate"
+	clientCertificateDownloadUri = "%s/auth/admin/realms/%s/clients/%s/certificates/%s/download"
+)
+
+func (c *KeycloakClient) GetClientCertificate(id, realm, attr string) (*ClientCertificate, error) {
+	url := fmt.Sprintf(clientCertificateUri, c.url, realm, id, attr)
+
+	var cert ClientCertificate
+	err := c.get(url, &cert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// Generates a new key pair and certificate for the client. The private key is only ever returned by this call.
+func (c *KeycloakClient) GenerateClientCertificate(id, realm, attr string) (*ClientCertificate, error) {
+	url := fmt.Sprintf(clientCertificateGenerateUri, c.url, realm, id, attr)
+
+	var cert ClientCertificate
+	err := c.postForResult(url, nil, &cert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// Uploads a keystore (including the private key) or just a certificate / public key, depending on the format.
+func (c *KeycloakClient) UploadClientCertificate(id, realm, attr string, upload *ClientCertificateUpload) (*ClientCertificate, error) {
+	url := fmt.Sprintf(clientCertificateUploadCert, c.url, realm, id, attr)
+	if upload.Format == "JKS" || upload.Format == "PKCS12" {
+		url = fmt.Sprintf(clientCertificateUploadUri, c.url, realm, id, attr)
+	}
+
+	fields := map[string]string{
+		"keystoreFormat": upload.Format,
+		"keyAlias":       upload.KeyAlias,
+		"keyPassword":    upload.KeyPassword,
+		"storePassword":  upload.StorePassword,
+	}
+
+	var cert ClientCertificate
+	err := c.postMultipart(url, fields, upload.File, &cert)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &cert, nil
+}
+
+// Returns a keystore (in the format given by the config) containing the client's keys.
+func (c *KeycloakClient) DownloadClientKeystore(id, realm, attr string, config *KeyStoreConfig) ([]byte, error) {
+	url := fmt.Sprintf(clientCertificateDownloadUri, c.url, realm, id, attr)
+	return c.postForRaw(url, *config)
+}
@@ -0,0 +1,122 @@
+package keycloak
+
+import (
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+// Component resource as documented in the Keycloak REST API docs. Components are the generic building block for
+// pluggable Keycloak features like user federation, LDAP mappers and key providers.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_componentrepresentation
+type Component struct {
+	Id           string          `json:"id,omitempty"`
+	Name         string          `json:"name"`
+	ProviderId   string          `json:"providerId"`
+	ProviderType string          `json:"providerType"`
+	ParentId     string          `json:"parentId,omitempty"`
+	SubType      string          `json:"subType,omitempty"`
+	Config       ComponentConfig `json:"config"`
+}
+
+// Keycloak stores every component config value as a list (a MultivaluedHashMap), even though most of them only ever
+// hold a single value.
+type ComponentConfig map[string][]string
+
+// Keycloak returns this instead of secret values (e.g. passwords), in component and identity provider configs as well
+// as for the SMTP password of realms. Sending it back keeps the stored secret.
+const ComponentSecretValue = "**********"
+
+const (
+	componentsUri = "%s/auth/admin/realms/%s/components"
+	componentUri  = "%s/auth/admin/realms/%s/components/%s"
+)
+
+// Returns the first value of the key, or an empty string if it isn't set.
+func (cc ComponentConfig) Get(key string) string {
+	if values := cc[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (cc ComponentConfig) GetBool(key string) bool {
+	b, _ := strconv.ParseBool(cc.Get(key))
+	return b
+}
+
+func (cc ComponentConfig) GetInt(key string) int {
+	i, _ := strconv.Atoi(cc.Get(key))
+	return i
+}
+
+func (cc ComponentConfig) Set(key, value string) {
+	cc[key] = []string{value}
+}
+
+func (cc ComponentConfig) SetBool(key string, value bool) {
+	cc.Set(key, strconv.FormatBool(value))
+}
+
+func (cc ComponentConfig) SetInt(key string, value int) {
+	cc.Set(key, strconv.Itoa(value))
+}
+
+func (c *KeycloakClient) GetComponent(id, realm string) (*Component, error) {
+	url := fmt.Sprintf(componentUri, c.url, realm, id)
+
+	var component Component
+	err := c.get(url, &component)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &component, nil
+}
+
+// Lists the components with the given parent and provider type, both are optional.
+func (c *KeycloakClient) ListComponents(realm, parentId, providerType string) ([]*Component, error) {
+	query := neturl.Values{}
+	if parentId != "" {
+		query.Set("parent", parentId)
+	}
+	if providerType != "" {
+		query.Set("type", providerType)
+	}
+
+	url := fmt.Sprintf(componentsUri, c.url, realm) + "?" + query.Encode()
+
+	var components []*Component
+	err := c.get(url, &components)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func (c *KeycloakClient) CreateComponent(component *Component, realm string) (*Component, error) {
+	url := fmt.Sprintf(componentsUri, c.url, realm)
+
+	componentLocation, err := c.post(url, *component)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdComponent Component
+	err = c.get(componentLocation, &createdComponent)
+
+	return &createdComponent, err
+}
+
+func (c *KeycloakClient) UpdateComponent(component *Component, realm string) error {
+	url := fmt.Sprintf(componentUri, c.url, realm, component.Id)
+	return c.put(url, *component)
+}
+
+func (c *KeycloakClient) DeleteComponent(id, realm string) error {
+	url := fmt.Sprintf(componentUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
@@ -0,0 +1,144 @@
+package keycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+)
+
+// Login event as documented in the Keycloak REST API docs.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_eventrepresentation
+type Event struct {
+	Time      int64             `json:"time"` // in milliseconds since the epoch
+	Type      string            `json:"type"`
+	RealmId   string            `json:"realmId"`
+	ClientId  string            `json:"clientId"`
+	UserId    string            `json:"userId"`
+	SessionId string            `json:"sessionId"`
+	IpAddress string            `json:"ipAddress"`
+	Error     string            `json:"error"`
+	Details   map[string]string `json:"details"`
+}
+
+// Admin event as documented in the Keycloak REST API docs. The representation is only included if the realm stores
+// admin event details.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_admineventrepresentation
+type AdminEvent struct {
+	Time           int64          `json:"time"` // in milliseconds since the epoch
+	RealmId        string         `json:"realmId"`
+	AuthDetails    AdminEventAuth `json:"authDetails"`
+	OperationType  string         `json:"operationType"`
+	ResourceType   string         `json:"resourceType"`
+	ResourcePath   string         `json:"resourcePath"`
+	Representation string         `json:"representation"`
+	Error          string         `json:"error"`
+}
+
+// Who caused an admin event
+type AdminEventAuth struct {
+	RealmId   string `json:"realmId"`
+	ClientId  string `json:"clientId"`
+	UserId    string `json:"userId"`
+	IpAddress string `json:"ipAddress"`
+}
+
+// Filters for listing login events, empty fields don't filter. Dates are formatted like `2006-01-02`.
+type EventFilter struct {
+	Types     []string
+	Client    string
+	User      string
+	IpAddress string
+	DateFrom  string
+	DateTo    string
+}
+
+// Filters for listing admin events, empty fields don't filter. Dates are formatted like `2006-01-02`.
+type AdminEventFilter struct {
+	OperationTypes []string
+	ResourceTypes  []string
+	ResourcePath   string
+	AuthRealm      string
+	AuthClient     string
+	AuthUser       string
+	AuthIpAddress  string
+	DateFrom       string
+	DateTo         string
+}
+
+const (
+	eventsUri      = "%s/auth/admin/realms/%s/events"
+	adminEventsUri = "%s/auth/admin/realms/%s/admin-events"
+)
+
+func setQueryValue(query neturl.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// Lists the realm's login events matching the filter, newest first, up to max events.
+func (c *KeycloakClient) ListEvents(realm string, filter *EventFilter, max int) ([]*Event, error) {
+	url := fmt.Sprintf(eventsUri, c.url, realm)
+
+	query := neturl.Values{}
+	for _, t := range filter.Types {
+		query.Add("type", t)
+	}
+	setQueryValue(query, "client", filter.Client)
+	setQueryValue(query, "user", filter.User)
+	setQueryValue(query, "ipAddress", filter.IpAddress)
+	setQueryValue(query, "dateFrom", filter.DateFrom)
+	setQueryValue(query, "dateTo", filter.DateTo)
+
+	events := []*Event{}
+	err := c.getPages(url, query, max, func(body []byte) (int, error) {
+		var page []*Event
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		events = append(events, page...)
+		return len(page), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Lists the realm's admin events matching the filter, newest first, up to max events.
+func (c *KeycloakClient) ListAdminEvents(realm string, filter *AdminEventFilter, max int) ([]*AdminEvent, error) {
+	url := fmt.Sprintf(adminEventsUri, c.url, realm)
+
+	query := neturl.Values{}
+	for _, t := range filter.OperationTypes {
+		query.Add("operationTypes", t)
+	}
+	for _, t := range filter.ResourceTypes {
+		query.Add("resourceTypes", t)
+	}
+	setQueryValue(query, "resourcePath", filter.ResourcePath)
+	setQueryValue(query, "authRealm", filter.AuthRealm)
+	setQueryValue(query, "authClient", filter.AuthClient)
+	setQueryValue(query, "authUser", filter.AuthUser)
+	setQueryValue(query, "authIpAddress", filter.AuthIpAddress)
+	setQueryValue(query, "dateFrom", filter.DateFrom)
+	setQueryValue(query, "dateTo", filter.DateTo)
+
+	events := []*AdminEvent{}
+	err := c.getPages(url, query, max, func(body []byte) (int, error) {
+		var page []*AdminEvent
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		events = append(events, page...)
+		return len(page), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
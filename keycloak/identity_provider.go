@@ -0,0 +1,112 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Identity provider resource as documented in the Keycloak REST API docs. The provider specific settings (endpoints,
+// credentials, etc.) live in the untyped config map.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_identityproviderrepresentation
+type IdentityProvider struct {
+	InternalId                string            `json:"internalId,omitempty"`
+	Alias                     string            `json:"alias"`
+	DisplayName               string            `json:"displayName,omitempty"`
+	ProviderId                string            `json:"providerId"`
+	Enabled                   bool              `json:"enabled"`
+	TrustEmail                bool              `json:"trustEmail"`
+	StoreToken                bool              `json:"storeToken"`
+	AddReadTokenRoleOnCreate  bool              `json:"addReadTokenRoleOnCreate"`
+	LinkOnly                  bool              `json:"linkOnly"`
+	FirstBrokerLoginFlowAlias string            `json:"firstBrokerLoginFlowAlias,omitempty"`
+	PostBrokerLoginFlowAlias  string            `json:"postBrokerLoginFlowAlias,omitempty"`
+	Config                    map[string]string `json:"config"`
+}
+
+const (
+	identityProvidersUri      = "%s/auth/admin/realms/%s/identity-provider/instances"
+	identityProviderUri       = "%s/auth/admin/realms/%s/identity-provider/instances/%s"
+	identityProviderImportUri = "%s/auth/admin/realms/%s/identity-provider/import-config"
+)
+
+func (c *KeycloakClient) GetIdentityProvider(alias, realm string) (*IdentityProvider, error) {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, alias)
+
+	var idp IdentityProvider
+	err := c.get(url, &idp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &idp, nil
+}
+
+func (c *KeycloakClient) ListIdentityProviders(realm string) ([]*IdentityProvider, error) {
+	url := fmt.Sprintf(identityProvidersUri, c.url, realm)
+
+	var idps []*IdentityProvider
+	err := c.get(url, &idps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return idps, nil
+}
+
+func (c *KeycloakClient) CreateIdentityProvider(idp *IdentityProvider, realm string) (*IdentityProvider, error) {
+	url := fmt.Sprintf(identityProvidersUri, c.url, realm)
+
+	_, err := c.post(url, *idp)
+	if err != nil {
+		return nil, err
+	}
+
+	// The alias is the ID of an identity provider, so there is no need to follow the location
+	return c.GetIdentityProvider(idp.Alias, realm)
+}
+
+func (c *KeycloakClient) UpdateIdentityProvider(idp *IdentityProvider, realm string) error {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, idp.Alias)
+	return c.put(url, *idp)
+}
+
+func (c *KeycloakClient) DeleteIdentityProvider(alias, realm string) error {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, alias)
+	return c.delete(url, nil)
+}
+
+// Has Keycloak parse the metadata (e.g. a SAML entity descriptor) at the given URL into an identity provider config.
+func (c *KeycloakClient) ImportIdentityProviderConfigFromUrl(realm, providerId, fromUrl string) (map[string]string, error) {
+	url := fmt.Sprintf(identityProviderImportUri, c.url, realm)
+	body := map[string]string{
+		"providerId": providerId,
+		"fromUrl":    fromUrl,
+	}
+
+	var config map[string]string
+	err := c.postForResult(url, body, &config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Has Keycloak parse the given metadata (e.g. a SAML entity descriptor) into an identity provider config.
+func (c *KeycloakClient) ImportIdentityProviderConfigFromFile(realm, providerId string, file []byte) (map[string]string, error) {
+	url := fmt.Sprintf(identityProviderImportUri, c.url, realm)
+	fields := map[string]string{
+		"providerId": providerId,
+	}
+
+	var config map[string]string
+	err := c.postMultipart(url, fields, file, &config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,70 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Identity provider mapper resource as documented in the Keycloak REST API docs.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_identityprovidermapperrepresentation
+type IdentityProviderMapper struct {
+	Id                     string            `json:"id,omitempty"`
+	Name                   string            `json:"name"`
+	IdentityProviderAlias  string            `json:"identityProviderAlias"`
+	IdentityProviderMapper string            `json:"identityProviderMapper"`
+	Config                 map[string]string `json:"config"`
+}
+
+const (
+	identityProviderMappersUri = "%s/auth/admin/realms/%s/identity-provider/instances/%s/mappers"
+	identityProviderMapperUri  = "%s/auth/admin/realms/%s/identity-provider/instances/%s/mappers/%s"
+)
+
+func (c *KeycloakClient) GetIdentityProviderMapper(id, realm, alias string) (*IdentityProviderMapper, error) {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, alias, id)
+
+	var mapper IdentityProviderMapper
+	err := c.get(url, &mapper)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapper, nil
+}
+
+func (c *KeycloakClient) ListIdentityProviderMappers(realm, alias string) ([]*IdentityProviderMapper, error) {
+	url := fmt.Sprintf(identityProviderMappersUri, c.url, realm, alias)
+
+	var mappers []*IdentityProviderMapper
+	err := c.get(url, &mappers)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers, nil
+}
+
+func (c *KeycloakClient) CreateIdentityProviderMapper(mapper *IdentityProviderMapper, realm, alias string) (*IdentityProviderMapper, error) {
+	url := fmt.Sprintf(identityProviderMappersUri, c.url, realm, alias)
+
+	mapperLocation, err := c.post(url, *mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdMapper IdentityProviderMapper
+	err = c.get(mapperLocation, &createdMapper)
+
+	return &createdMapper, err
+}
+
+func (c *KeycloakClient) UpdateIdentityProviderMapper(mapper *IdentityProviderMapper, realm, alias string) error {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, alias, mapper.Id)
+	return c.put(url, *mapper)
+}
+
+func (c *KeycloakClient) DeleteIdentityProviderMapper(id, realm, alias string) error {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, alias, id)
+	return c.delete(url, nil)
+}
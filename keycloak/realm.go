@@ -4,8 +4,22 @@ import (
 	"fmt"
 )
 
-// The available keys of the SMTP server map are not documented in Keycloak's API docs.
-type SmtpServer map[string]interface{}
+// The SMTP server keys are not documented in Keycloak's API docs. Keycloak stores all of them as strings, including
+// the port and the flags.
+type SmtpServer struct {
+	Host               string `json:"host,omitempty"`
+	Port               string `json:"port,omitempty"`
+	From               string `json:"from,omitempty"`
+	FromDisplayName    string `json:"fromDisplayName,omitempty"`
+	ReplyTo            string `json:"replyTo,omitempty"`
+	ReplyToDisplayName string `json:"replyToDisplayName,omitempty"`
+	EnvelopeFrom       string `json:"envelopeFrom,omitempty"`
+	Starttls           string `json:"starttls,omitempty"`
+	Ssl                string `json:"ssl,omitempty"`
+	Auth               string `json:"auth,omitempty"`
+	User               string `json:"user,omitempty"`
+	Password           string `json:"password,omitempty"` // Keycloak returns a mask instead
+}
 
 // Representation of top-level realm keys. According to the Keycloak documentation other keys than top-level keys will
 // be ignored on realm updates, which is why they are not included here.
@@ -22,12 +36,24 @@ type Realm struct {
 	SupportedLocales []string    `json:"supportedLocales,omitempty"`
 	DefaultRoles     []string    `json:"defaultRoles,omitempty"`
 	SmtpServer       *SmtpServer `json:"smtpServer,omitempty"`
+	PasswordPolicy   *string     `json:"passwordPolicy,omitempty"` // e.g. `length(12) and digits(1)`
+
+	// Browser security headers by key, e.g. `xFrameOptions`
+	BrowserSecurityHeaders map[string]string `json:"browserSecurityHeaders,omitempty"`
 
 	AccountTheme string `json:"accountTheme,omitempty"`
 	AdminTheme   string `json:"adminTheme,omitempty"`
 	EmailTheme   string `json:"emailTheme,omitempty"`
 	LoginTheme   string `json:"loginTheme,omitempty"`
 
+	// Authentication flow bindings, by flow alias
+	BrowserFlow              string `json:"browserFlow,omitempty"`
+	RegistrationFlow         string `json:"registrationFlow,omitempty"`
+	DirectGrantFlow          string `json:"directGrantFlow,omitempty"`
+	ResetCredentialsFlow     string `json:"resetCredentialsFlow,omitempty"`
+	ClientAuthenticationFlow string `json:"clientAuthenticationFlow,omitempty"`
+	DockerAuthenticationFlow string `json:"dockerAuthenticationFlow,omitempty"`
+
 	InternationalizationEnabled *bool `json:"internationalizationEnabled,omitempty"`
 	RegistrationAllowed         *bool `json:"registrationAllowed,omitempty"`
 	RegistrationEmailAsUsername *bool `json:"registrationEmailAsUsername,omitempty"`
@@ -36,6 +62,39 @@ type Realm struct {
 	ResetPasswordAllowed        *bool `json:"resetPasswordAllowed,omitempty"`
 	EditUsernameAllowed         *bool `json:"editUsernameAllowed,omitempty"`
 	BruteForceProtected         *bool `json:"bruteForceProtected,omitempty"`
+	PermanentLockout            *bool `json:"permanentLockout,omitempty"`
+
+	// OTP policy
+	OtpPolicyType            string   `json:"otpPolicyType,omitempty"`
+	OtpPolicyAlgorithm       string   `json:"otpPolicyAlgorithm,omitempty"`
+	OtpPolicyInitialCounter  *int     `json:"otpPolicyInitialCounter,omitempty"`
+	OtpPolicyDigits          *int     `json:"otpPolicyDigits,omitempty"`
+	OtpPolicyLookAheadWindow *int     `json:"otpPolicyLookAheadWindow,omitempty"`
+	OtpPolicyPeriod          *int     `json:"otpPolicyPeriod,omitempty"`
+	OtpSupportedApplications []string `json:"otpSupportedApplications,omitempty"` // read only, derived from the policy
+
+	// WebAuthn policies, for two factor authentication and for passwordless logins. The AAGUID lists are sent even
+	// when empty, as only an empty list clears them (Keycloak ignores null).
+	WebAuthnPolicyRpEntityName                                string   `json:"webAuthnPolicyRpEntityName,omitempty"`
+	WebAuthnPolicySignatureAlgorithms                         []string `json:"webAuthnPolicySignatureAlgorithms,omitempty"`
+	WebAuthnPolicyRpId                                        *string  `json:"webAuthnPolicyRpId,omitempty"`
+	WebAuthnPolicyAttestationConveyancePreference             string   `json:"webAuthnPolicyAttestationConveyancePreference,omitempty"`
+	WebAuthnPolicyAuthenticatorAttachment                     string   `json:"webAuthnPolicyAuthenticatorAttachment,omitempty"`
+	WebAuthnPolicyRequireResidentKey                          string   `json:"webAuthnPolicyRequireResidentKey,omitempty"`
+	WebAuthnPolicyUserVerificationRequirement                 string   `json:"webAuthnPolicyUserVerificationRequirement,omitempty"`
+	WebAuthnPolicyCreateTimeout                               *int     `json:"webAuthnPolicyCreateTimeout,omitempty"`
+	WebAuthnPolicyAvoidSameAuthenticatorRegister              *bool    `json:"webAuthnPolicyAvoidSameAuthenticatorRegister,omitempty"`
+	WebAuthnPolicyAcceptableAaguids                           []string `json:"webAuthnPolicyAcceptableAaguids"`
+	WebAuthnPolicyPasswordlessRpEntityName                    string   `json:"webAuthnPolicyPasswordlessRpEntityName,omitempty"`
+	WebAuthnPolicyPasswordlessSignatureAlgorithms             []string `json:"webAuthnPolicyPasswordlessSignatureAlgorithms,omitempty"`
+	WebAuthnPolicyPasswordlessRpId                            *string  `json:"webAuthnPolicyPasswordlessRpId,omitempty"`
+	WebAuthnPolicyPasswordlessAttestationConveyancePreference string   `json:"webAuthnPolicyPasswordlessAttestationConveyancePreference,omitempty"`
+	WebAuthnPolicyPasswordlessAuthenticatorAttachment         string   `json:"webAuthnPolicyPasswordlessAuthenticatorAttachment,omitempty"`
+	WebAuthnPolicyPasswordlessRequireResidentKey              string   `json:"webAuthnPolicyPasswordlessRequireResidentKey,omitempty"`
+	WebAuthnPolicyPasswordlessUserVerificationRequirement     string   `json:"webAuthnPolicyPasswordlessUserVerificationRequirement,omitempty"`
+	WebAuthnPolicyPasswordlessCreateTimeout                   *int     `json:"webAuthnPolicyPasswordlessCreateTimeout,omitempty"`
+	WebAuthnPolicyPasswordlessAvoidSameAuthenticatorRegister  *bool    `json:"webAuthnPolicyPasswordlessAvoidSameAuthenticatorRegister,omitempty"`
+	WebAuthnPolicyPasswordlessAcceptableAaguids               []string `json:"webAuthnPolicyPasswordlessAcceptableAaguids"`
 
 	// Token & session settings
 	AccessTokenLifespan                *int `json:"accessTokenLifespan,omitempty"`
@@ -0,0 +1,38 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Event settings of a realm as documented in the Keycloak REST API docs.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_realmeventsconfigrepresentation
+type RealmEventsConfig struct {
+	EventsEnabled             bool     `json:"eventsEnabled"`
+	EventsExpiration          int      `json:"eventsExpiration,omitempty"` // in seconds, events are kept forever if unset
+	EnabledEventTypes         []string `json:"enabledEventTypes"`
+	AdminEventsEnabled        bool     `json:"adminEventsEnabled"`
+	AdminEventsDetailsEnabled bool     `json:"adminEventsDetailsEnabled"`
+	EventsListeners           []string `json:"eventsListeners"`
+}
+
+const (
+	realmEventsConfigUri = "%s/auth/admin/realms/%s/events/config"
+)
+
+func (c *KeycloakClient) GetRealmEventsConfig(realm string) (*RealmEventsConfig, error) {
+	url := fmt.Sprintf(realmEventsConfigUri, c.url, realm)
+
+	var config RealmEventsConfig
+	err := c.get(url, &config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+func (c *KeycloakClient) UpdateRealmEventsConfig(config *RealmEventsConfig, realm string) error {
+	url := fmt.Sprintf(realmEventsConfigUri, c.url, realm)
+	return c.put(url, *config)
+}
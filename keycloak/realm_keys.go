@@ -0,0 +1,40 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Key of a realm as listed by Keycloak, ProviderId is the ID of the key provider component holding the key. Symmetric
+// keys (HMAC and AES) have no public key or certificate.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_keysmetadatarepresentation-keymetadatarepresentation
+type RealmKey struct {
+	ProviderId       string `json:"providerId"`
+	ProviderPriority int64  `json:"providerPriority"`
+	Kid              string `json:"kid"`
+	Status           string `json:"status"` // ACTIVE, PASSIVE or DISABLED
+	Type             string `json:"type"`   // RSA, EC, OCT
+	Algorithm        string `json:"algorithm,omitempty"`
+	PublicKey        string `json:"publicKey,omitempty"`
+	Certificate      string `json:"certificate,omitempty"`
+}
+
+type realmKeysMetadata struct {
+	Keys []*RealmKey `json:"keys"`
+}
+
+const (
+	realmKeysUri = "%s/auth/admin/realms/%s/keys"
+)
+
+func (c *KeycloakClient) ListRealmKeys(realm string) ([]*RealmKey, error) {
+	url := fmt.Sprintf(realmKeysUri, c.url, realm)
+
+	var metadata realmKeysMetadata
+	err := c.get(url, &metadata)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.Keys, nil
+}
@@ -0,0 +1,87 @@
+package keycloak
+
+import (
+	"fmt"
+	neturl "net/url"
+)
+
+// Required action resource as documented in the Keycloak REST API docs.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_requiredactionproviderrepresentation
+type RequiredAction struct {
+	Alias         string            `json:"alias"`
+	Name          string            `json:"name"`
+	ProviderId    string            `json:"providerId,omitempty"`
+	Enabled       bool              `json:"enabled"`
+	DefaultAction bool              `json:"defaultAction"`
+	Priority      int               `json:"priority"`
+	Config        map[string]string `json:"config"`
+}
+
+// Required action provider which is deployed, but not registered with the realm yet.
+type UnregisteredRequiredAction struct {
+	ProviderId string `json:"providerId"`
+	Name       string `json:"name"`
+}
+
+const (
+	requiredActionsUri             = "%s/auth/admin/realms/%s/authentication/required-actions"
+	requiredActionUri              = "%s/auth/admin/realms/%s/authentication/required-actions/%s"
+	unregisteredRequiredActionsUri = "%s/auth/admin/realms/%s/authentication/unregistered-required-actions"
+	registerRequiredActionUri      = "%s/auth/admin/realms/%s/authentication/register-required-action"
+)
+
+func (c *KeycloakClient) GetRequiredAction(alias, realm string) (*RequiredAction, error) {
+	url := fmt.Sprintf(requiredActionUri, c.url, realm, neturl.PathEscape(alias))
+
+	var action RequiredAction
+	err := c.get(url, &action)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &action, nil
+}
+
+func (c *KeycloakClient) ListRequiredActions(realm string) ([]*RequiredAction, error) {
+	url := fmt.Sprintf(requiredActionsUri, c.url, realm)
+
+	var actions []*RequiredAction
+	err := c.get(url, &actions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+func (c *KeycloakClient) ListUnregisteredRequiredActions(realm string) ([]*UnregisteredRequiredAction, error) {
+	url := fmt.Sprintf(unregisteredRequiredActionsUri, c.url, realm)
+
+	var actions []*UnregisteredRequiredAction
+	err := c.get(url, &actions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// Registers a deployed required action provider with the realm, its alias is the provider ID.
+func (c *KeycloakClient) RegisterRequiredAction(action *UnregisteredRequiredAction, realm string) error {
+	url := fmt.Sprintf(registerRequiredActionUri, c.url, realm)
+	return c.postForResult(url, *action, nil)
+}
+
+func (c *KeycloakClient) UpdateRequiredAction(action *RequiredAction, realm string) error {
+	url := fmt.Sprintf(requiredActionUri, c.url, realm, neturl.PathEscape(action.Alias))
+	return c.put(url, *action)
+}
+
+// Unregisters the required action, it can be registered again afterwards.
+func (c *KeycloakClient) DeleteRequiredAction(alias, realm string) error {
+	url := fmt.Sprintf(requiredActionUri, c.url, realm, neturl.PathEscape(alias))
+	return c.delete(url, nil)
+}
@@ -0,0 +1,35 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Parts of the server info (the features and providers the Keycloak server offers) used by the provider.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_serverinforepresentation
+type ServerInfo struct {
+	PasswordPolicies []*PasswordPolicyType `json:"passwordPolicies"`
+}
+
+// A password policy provider, which can be used in a realm's password policy by its ID.
+type PasswordPolicyType struct {
+	Id                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	ConfigType        string `json:"configType"`
+	DefaultValue      string `json:"defaultValue"`
+	MultipleSupported bool   `json:"multipleSupported"`
+}
+
+const serverInfoUri = "%s/auth/admin/serverinfo"
+
+func (c *KeycloakClient) GetServerInfo() (*ServerInfo, error) {
+	url := fmt.Sprintf(serverInfoUri, c.url)
+
+	var info ServerInfo
+	err := c.get(url, &info)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,51 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Result of synchronizing users (or a mapper) between Keycloak and a user storage provider like LDAP.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_synchronizationresult
+type SynchronizationResult struct {
+	Ignored bool   `json:"ignored"`
+	Added   int    `json:"added"`
+	Updated int    `json:"updated"`
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed"`
+	Status  string `json:"status"`
+}
+
+const (
+	userStorageSyncUri       = "%s/auth/admin/realms/%s/user-storage/%s/sync?action=%s"
+	userStorageMapperSyncUri = "%s/auth/admin/realms/%s/user-storage/%s/mappers/%s/sync?direction=%s"
+)
+
+// Synchronizes the users of a user storage provider, the action is either `triggerFullSync` or
+// `triggerChangedUsersSync`.
+func (c *KeycloakClient) SyncUserStorage(id, realm, action string) (*SynchronizationResult, error) {
+	url := fmt.Sprintf(userStorageSyncUri, c.url, realm, id, action)
+
+	var result SynchronizationResult
+	err := c.postForResult(url, nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Synchronizes the data of a user storage mapper (e.g. LDAP groups), the direction is either `fedToKeycloak` or
+// `keycloakToFed`.
+func (c *KeycloakClient) SyncUserStorageMapper(id, parentId, realm, direction string) (*SynchronizationResult, error) {
+	url := fmt.Sprintf(userStorageMapperSyncUri, c.url, realm, parentId, id, direction)
+
+	var result SynchronizationResult
+	err := c.postForResult(url, nil, &result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
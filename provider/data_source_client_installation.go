@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceClientInstallation() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClientInstallationRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// n.b. this is the ID of the client, not its name
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// e.g. keycloak-oidc-keycloak-json, keycloak-oidc-jboss-subsystem, saml-idp-descriptor, saml-sp-descriptor
+			"provider_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Empty for binary formats (e.g. the mod-auth-mellon zip file), use content_base64 for those.
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_base64": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// Only set for JSON formats. Nested keys are joined with dots, e.g. `credentials.secret`.
+			"attributes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceClientInstallationRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	providerId := d.Get("provider_id").(string)
+
+	installation, err := c.GetClientInstallation(client(d), realm(d), providerId)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", client(d), providerId))
+	d.Set("content_base64", base64.StdEncoding.EncodeToString([]byte(installation)))
+
+	if utf8.ValidString(installation) {
+		d.Set("content", installation)
+	} else {
+		d.Set("content", "")
+	}
+
+	// Only JSON objects have attributes, other formats (and JSON values like a plain number) have none
+	attributes := map[string]interface{}{}
+	// UseNumber keeps large integers (which don't fit a float64) as they are
+	var parsed map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(installation))
+	decoder.UseNumber()
+	if decoder.Decode(&parsed) == nil && !decoder.More() {
+		flattenJson("", parsed, attributes)
+	}
+	d.Set("attributes", attributes)
+
+	return nil
+}
+
+// Terraform maps can only hold strings, so nested objects and lists are flattened into dotted keys.
+func flattenJson(prefix string, v interface{}, result map[string]interface{}) {
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, nested := range value {
+			flattenJson(key(k), nested, result)
+		}
+	case []interface{}:
+		for i, nested := range value {
+			flattenJson(key(fmt.Sprintf("%d", i)), nested, result)
+		}
+	case nil:
+		result[prefix] = ""
+	case json.Number:
+		result[prefix] = value.String()
+	default:
+		result[prefix] = fmt.Sprintf("%v", value)
+	}
+}
@@ -0,0 +1,253 @@
+// This file provides data sources for the stored login and admin events of a realm. Events are only stored if
+// enabled, e.g. with keycloak_realm_events.
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEventsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// e.g. LOGIN_ERROR
+			"types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// n.b. this is the client_id of the client, not its ID
+			"client_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"date_from":   eventDateSchema(),
+			"date_to":     eventDateSchema(),
+			"max_results": eventMaxResultsSchema(),
+			// Newest first
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time":       computedStringSchema(),
+						"type":       computedStringSchema(),
+						"client_id":  computedStringSchema(),
+						"user_id":    computedStringSchema(),
+						"session_id": computedStringSchema(),
+						"ip_address": computedStringSchema(),
+						"error":      computedStringSchema(),
+						"details": {
+							Type:     schema.TypeMap,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAdminEvents() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAdminEventsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// e.g. CREATE, UPDATE, DELETE or ACTION
+			"operation_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// e.g. USER or CLIENT
+			"resource_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// May contain `*` wildcards, e.g. `users/*`
+			"resource_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The realm, client (by ID), user and IP address of the admin
+			"auth_realm": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_client": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"auth_ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"date_from":   eventDateSchema(),
+			"date_to":     eventDateSchema(),
+			"max_results": eventMaxResultsSchema(),
+			// Newest first
+			"events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"time":            computedStringSchema(),
+						"operation_type":  computedStringSchema(),
+						"resource_type":   computedStringSchema(),
+						"resource_path":   computedStringSchema(),
+						"representation":  computedStringSchema(),
+						"error":           computedStringSchema(),
+						"auth_realm":      computedStringSchema(),
+						"auth_client":     computedStringSchema(),
+						"auth_user":       computedStringSchema(),
+						"auth_ip_address": computedStringSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func computedStringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+// Formatted like 2006-01-02, both dates are inclusive
+func eventDateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateEventDate,
+	}
+}
+
+// Events are fetched in pages until this many were found
+func eventMaxResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      100,
+		ValidateFunc: validateIntAtLeast(1),
+	}
+}
+
+func validateEventDate(v interface{}, k string) (w []string, err []error) {
+	if _, parseErr := time.Parse("2006-01-02", v.(string)); parseErr != nil {
+		err = []error{
+			fmt.Errorf("Invalid value for %s. Dates are formatted like 2006-01-02", k),
+		}
+	}
+	return
+}
+
+// Keycloak returns times in milliseconds since the epoch, they are shown in RFC 3339 format.
+func formatEventTime(millis int64) string {
+	return time.Unix(0, millis*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+func dataSourceEventsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	filter := &keycloak.EventFilter{
+		Types:     getStringSlice(d, "types"),
+		Client:    d.Get("client_id").(string),
+		User:      d.Get("user_id").(string),
+		IpAddress: d.Get("ip_address").(string),
+		DateFrom:  d.Get("date_from").(string),
+		DateTo:    d.Get("date_to").(string),
+	}
+
+	events, err := c.ListEvents(realm(d), filter, d.Get("max_results").(int))
+	if err != nil {
+		return err
+	}
+
+	list := []interface{}{}
+	for _, event := range events {
+		list = append(list, map[string]interface{}{
+			"time":       formatEventTime(event.Time),
+			"type":       event.Type,
+			"client_id":  event.ClientId,
+			"user_id":    event.UserId,
+			"session_id": event.SessionId,
+			"ip_address": event.IpAddress,
+			"error":      event.Error,
+			"details":    event.Details,
+		})
+	}
+
+	d.SetId(realm(d))
+	d.Set("events", list)
+
+	return nil
+}
+
+func dataSourceAdminEventsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	filter := &keycloak.AdminEventFilter{
+		OperationTypes: getStringSlice(d, "operation_types"),
+		ResourceTypes:  getStringSlice(d, "resource_types"),
+		ResourcePath:   d.Get("resource_path").(string),
+		AuthRealm:      d.Get("auth_realm").(string),
+		AuthClient:     d.Get("auth_client").(string),
+		AuthUser:       d.Get("auth_user").(string),
+		AuthIpAddress:  d.Get("auth_ip_address").(string),
+		DateFrom:       d.Get("date_from").(string),
+		DateTo:         d.Get("date_to").(string),
+	}
+
+	events, err := c.ListAdminEvents(realm(d), filter, d.Get("max_results").(int))
+	if err != nil {
+		return err
+	}
+
+	list := []interface{}{}
+	for _, event := range events {
+		list = append(list, map[string]interface{}{
+			"time":            formatEventTime(event.Time),
+			"operation_type":  event.OperationType,
+			"resource_type":   event.ResourceType,
+			"resource_path":   event.ResourcePath,
+			"representation":  event.Representation,
+			"error":           event.Error,
+			"auth_realm":      event.AuthDetails.RealmId,
+			"auth_client":     event.AuthDetails.ClientId,
+			"auth_user":       event.AuthDetails.UserId,
+			"auth_ip_address": event.AuthDetails.IpAddress,
+		})
+	}
+
+	d.SetId(realm(d))
+	d.Set("events", list)
+
+	return nil
+}
@@ -11,14 +11,58 @@ func Provider() terraform.ResourceProvider {
 		Schema:        keycloakProviderSchema(),
 		ConfigureFunc: schema.ConfigureFunc(keycloakProviderSetup),
 		DataSourcesMap: map[string]*schema.Resource{
-			"keycloak_client": dataSourceClient(),
+			"keycloak_client":              dataSourceClient(),
+			"keycloak_client_installation": dataSourceClientInstallation(),
+			"keycloak_events":              dataSourceEvents(),
+			"keycloak_admin_events":        dataSourceAdminEvents(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"keycloak_client":          resourceClient(),
-			"keycloak_realm":           resourceRealm(),
-			"keycloak_role":            resourceRole(),
-			"keycloak_role_mapping":    resourceRoleMapping(),
-			"keycloak_protocol_mapper": resourceProtocolMapper(),
+			"keycloak_client":                                              resourceClient(),
+			"keycloak_openid_client":                                       resourceOpenidClient(),
+			"keycloak_saml_client":                                         resourceSamlClient(),
+			"keycloak_realm":                                               resourceRealm(),
+			"keycloak_role":                                                resourceRole(),
+			"keycloak_role_mapping":                                        resourceRoleMapping(),
+			"keycloak_protocol_mapper":                                     resourceProtocolMapper(),
+			"keycloak_client_certificate":                                  resourceClientCertificate(),
+			"keycloak_oidc_identity_provider":                              resourceOidcIdentityProvider(),
+			"keycloak_saml_identity_provider":                              resourceSamlIdentityProvider(),
+			"keycloak_google_identity_provider":                            resourceGoogleIdentityProvider(),
+			"keycloak_github_identity_provider":                            resourceGithubIdentityProvider(),
+			"keycloak_microsoft_identity_provider":                         resourceMicrosoftIdentityProvider(),
+			"keycloak_gitlab_identity_provider":                            resourceGitlabIdentityProvider(),
+			"keycloak_identity_provider_mapper":                            resourceIdentityProviderMapper(),
+			"keycloak_attribute_importer_identity_provider_mapper":         resourceAttributeImporterIdentityProviderMapper(),
+			"keycloak_hardcoded_role_identity_provider_mapper":             resourceHardcodedRoleIdentityProviderMapper(),
+			"keycloak_claim_to_role_identity_provider_mapper":              resourceClaimToRoleIdentityProviderMapper(),
+			"keycloak_advanced_claim_to_group_identity_provider_mapper":    resourceAdvancedClaimToGroupIdentityProviderMapper(),
+			"keycloak_username_template_importer_identity_provider_mapper": resourceUsernameTemplateImporterIdentityProviderMapper(),
+			"keycloak_ldap_user_federation":                                resourceLdapUserFederation(),
+			"keycloak_ldap_user_attribute_mapper":                          resourceLdapUserAttributeMapper(),
+			"keycloak_ldap_full_name_mapper":                               resourceLdapFullNameMapper(),
+			"keycloak_ldap_group_mapper":                                   resourceLdapGroupMapper(),
+			"keycloak_ldap_role_mapper":                                    resourceLdapRoleMapper(),
+			"keycloak_ldap_hardcoded_role_mapper":                          resourceLdapHardcodedRoleMapper(),
+			"keycloak_ldap_hardcoded_group_mapper":                         resourceLdapHardcodedGroupMapper(),
+			"keycloak_ldap_msad_user_account_control_mapper":               resourceLdapMsadUserAccountControlMapper(),
+			"keycloak_ldap_certificate_mapper":                             resourceLdapCertificateMapper(),
+			"keycloak_user_federation_sync":                                resourceUserFederationSync(),
+			"keycloak_kerberos_user_federation":                            resourceKerberosUserFederation(),
+			"keycloak_custom_user_federation":                              resourceCustomUserFederation(),
+			"keycloak_component":                                           resourceComponent(),
+			"keycloak_authentication_flow":                                 resourceAuthenticationFlow(),
+			"keycloak_authentication_subflow":                              resourceAuthenticationSubflow(),
+			"keycloak_authentication_execution":                            resourceAuthenticationExecution(),
+			"keycloak_authentication_execution_order":                      resourceAuthenticationExecutionOrder(),
+			"keycloak_authentication_execution_config":                     resourceAuthenticationExecutionConfig(),
+			"keycloak_required_action":                                     resourceRequiredAction(),
+			"keycloak_realm_events":                                        resourceRealmEvents(),
+			"keycloak_realm_keystore_rsa":                                  resourceRealmKeystoreRsa(),
+			"keycloak_realm_keystore_rsa_generated":                        resourceRealmKeystoreRsaGenerated(),
+			"keycloak_realm_keystore_hmac_generated":                       resourceRealmKeystoreHmacGenerated(),
+			"keycloak_realm_keystore_aes_generated":                        resourceRealmKeystoreAesGenerated(),
+			"keycloak_realm_keystore_ecdsa_generated":                      resourceRealmKeystoreEcdsaGenerated(),
+			"keycloak_realm_keystore_java_keystore":                        resourceRealmKeystoreJavaKeystore(),
 		},
 	}
 }
@@ -0,0 +1,83 @@
+// This file provides a Terraform resource for mappers adding users to a group when their token contains all of the
+// given claims. Only OpenID Connect identity providers support this mapper.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Keycloak stores the claims as a JSON list of key/value pairs in the mapper config.
+type identityProviderMapperClaim struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func resourceAdvancedClaimToGroupIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: func(_ *schema.ResourceData, providerId string) (string, error) {
+			if !isOidcIdentityProvider(providerId) {
+				return "", fmt.Errorf("Advanced claim to group mappers can only be used with OpenID Connect identity providers, not %s", providerId)
+			}
+			return "oidc-advanced-group-idp-mapper", nil
+		},
+		schema: map[string]*schema.Schema{
+			// Claim names to the values they must have, all of them must match
+			"claims": {
+				Type:     schema.TypeMap,
+				Required: true,
+			},
+			"claim_values_regex": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// The path of the group, e.g. `/parent/child`
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			rawClaims := d.Get("claims").(map[string]interface{})
+			keys := []string{}
+			for k := range rawClaims {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			claims := []identityProviderMapperClaim{}
+			for _, k := range keys {
+				claims = append(claims, identityProviderMapperClaim{Key: k, Value: rawClaims[k].(string)})
+			}
+
+			claimsJson, err := json.Marshal(claims)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]string{
+				"claims":                 string(claimsJson),
+				"are.claim.values.regex": fmt.Sprintf("%t", d.Get("claim_values_regex").(bool)),
+				"group":                  d.Get("group").(string),
+			}, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			var claims []identityProviderMapperClaim
+			json.Unmarshal([]byte(config["claims"]), &claims)
+
+			claimsMap := map[string]interface{}{}
+			for _, claim := range claims {
+				claimsMap[claim.Key] = claim.Value
+			}
+
+			d.Set("claims", claimsMap)
+			d.Set("claim_values_regex", config["are.claim.values.regex"] == "true")
+			d.Set("group", config["group"])
+		},
+	})
+}
@@ -0,0 +1,101 @@
+// This file provides a Terraform resource for mappers importing a claim (OpenID Connect and social identity providers)
+// or an assertion attribute (SAML identity providers) into a user attribute.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAttributeImporterIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: attributeImporterMapperType,
+		schema: map[string]*schema.Schema{
+			"user_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// For OpenID Connect and social identity providers. Nested claims can be given as `address.country`.
+			"claim_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"attribute_name", "attribute_friendly_name"},
+			},
+			// For SAML identity providers, only one of these is needed.
+			"attribute_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"claim_name"},
+			},
+			"attribute_friendly_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"claim_name"},
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			userAttribute := d.Get("user_attribute").(string)
+
+			switch d.Get("identity_provider_mapper").(string) {
+			case "oidc-user-attribute-idp-mapper":
+				return map[string]string{
+					"claim":          d.Get("claim_name").(string),
+					"user.attribute": userAttribute,
+				}, nil
+			case "saml-user-attribute-idp-mapper":
+				return map[string]string{
+					"attribute.name":          d.Get("attribute_name").(string),
+					"attribute.friendly.name": d.Get("attribute_friendly_name").(string),
+					"user.attribute":          userAttribute,
+				}, nil
+			default:
+				// The social identity providers all use the same config for their attribute importers
+				return map[string]string{
+					"jsonField":     d.Get("claim_name").(string),
+					"userAttribute": userAttribute,
+				}, nil
+			}
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			if v, present := config["userAttribute"]; present {
+				d.Set("user_attribute", v)
+				d.Set("claim_name", config["jsonField"])
+				return
+			}
+
+			d.Set("user_attribute", config["user.attribute"])
+			if v, present := config["claim"]; present {
+				d.Set("claim_name", v)
+			} else {
+				d.Set("attribute_name", config["attribute.name"])
+				d.Set("attribute_friendly_name", config["attribute.friendly.name"])
+			}
+		},
+	})
+}
+
+func attributeImporterMapperType(d *schema.ResourceData, providerId string) (string, error) {
+	var mapperType string
+	if isOidcIdentityProvider(providerId) {
+		mapperType = "oidc-user-attribute-idp-mapper"
+	} else if providerId == "saml" {
+		mapperType = "saml-user-attribute-idp-mapper"
+	} else {
+		mapperType = fmt.Sprintf("%s-user-attribute-mapper", providerId)
+	}
+
+	// The config is built from this, so it must be known before the mapper is created
+	d.Set("identity_provider_mapper", mapperType)
+
+	if providerId == "saml" {
+		if d.Get("attribute_name").(string) == "" && d.Get("attribute_friendly_name").(string) == "" {
+			return "", fmt.Errorf("attribute_name or attribute_friendly_name must be set for SAML identity providers")
+		}
+	} else if d.Get("claim_name").(string) == "" {
+		return "", fmt.Errorf("claim_name must be set for %s identity providers", providerId)
+	}
+
+	return mapperType, nil
+}
@@ -0,0 +1,150 @@
+// This file provides a Terraform resource for the executions of an authenticator (e.g. `auth-otp-form`) in an
+// authentication flow. New executions are added to the end of their flow, the order can be changed with the execution
+// order resource.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationExecution() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationExecutionRead),
+		Create: schema.CreateFunc(resourceAuthenticationExecutionCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationExecutionUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationExecutionDelete),
+
+		// Executions are importable by ID, but the realm and flow alias must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationExecutionHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The alias of the flow (or subflow) the execution belongs to
+			"parent_flow_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The authenticator's provider ID, e.g. `auth-cookie` or `auth-otp-form`
+			"authenticator": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"requirement": authenticationRequirementSchema(),
+		},
+	}
+}
+
+// Subflows and executions both have a requirement, which determines how their outcome affects the parent flow.
+func authenticationRequirementSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "DISABLED",
+		ValidateFunc: validateOneOf("REQUIRED", "ALTERNATIVE", "CONDITIONAL", "DISABLED"),
+	}
+}
+
+// Keycloak adds executions and subflows as DISABLED, and only allows changing their requirement afterwards.
+func updateAuthenticationRequirement(c *keycloak.KeycloakClient, executionId, parentFlowAlias, realm, requirement string) error {
+	execution, err := c.GetAuthenticationExecutionInfo(executionId, parentFlowAlias, realm)
+	if err != nil {
+		return err
+	}
+
+	if execution.Requirement == requirement {
+		return nil
+	}
+
+	execution.Requirement = requirement
+	return c.UpdateAuthenticationExecution(execution, parentFlowAlias, realm)
+}
+
+func parentFlowAlias(d *schema.ResourceData) string {
+	return d.Get("parent_flow_alias").(string)
+}
+
+// Flow aliases usually contain spaces but no slashes, so the ID is split at the first and last slash.
+func splitRealmFlowAliasId(raw string) (string, string, string, error) {
+	first, last := strings.Index(raw, "/"), strings.LastIndex(raw, "/")
+	if first < 1 || last <= first+1 || last == len(raw)-1 {
+		return "", "", "", fmt.Errorf("Import ID must be specified as '${realm}/${parent_flow_alias}/${id}'")
+	}
+	return raw[:first], raw[first+1 : last], raw[last+1:], nil
+}
+
+func importAuthenticationExecutionHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, alias, id, err := splitRealmFlowAliasId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+	d.Set("parent_flow_alias", alias)
+
+	err = resourceAuthenticationExecutionRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationExecutionRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	execution, err := c.GetAuthenticationExecutionInfo(d.Id(), parentFlowAlias(d), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("authenticator", execution.ProviderId)
+	d.Set("requirement", execution.Requirement)
+
+	return nil
+}
+
+func resourceAuthenticationExecutionCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	id, err := c.CreateAuthenticationExecution(d.Get("authenticator").(string), parentFlowAlias(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(id)
+
+	return resourceAuthenticationExecutionUpdate(d, m)
+}
+
+func resourceAuthenticationExecutionUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	err := updateAuthenticationRequirement(c, d.Id(), parentFlowAlias(d), realm(d), d.Get("requirement").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationExecutionRead(d, m)
+}
+
+func resourceAuthenticationExecutionDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteAuthenticationExecution(d.Id(), realm(d))
+}
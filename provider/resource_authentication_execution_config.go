@@ -0,0 +1,134 @@
+// This file provides a Terraform resource for the config of an authentication execution, e.g. the settings of a
+// conditional OTP or identity provider redirector execution.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationExecutionConfig() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationExecutionConfigRead),
+		Create: schema.CreateFunc(resourceAuthenticationExecutionConfigCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationExecutionConfigUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationExecutionConfigDelete),
+
+		// Configs are importable by the ID of their execution, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationExecutionConfigHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"execution_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func importAuthenticationExecutionConfigHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*keycloak.KeycloakClient)
+
+	realm, executionId, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := c.GetAuthenticationExecution(executionId, realm)
+	if err != nil {
+		return nil, err
+	}
+	if execution.AuthenticatorConfig == "" {
+		return nil, fmt.Errorf("Execution %s has no config", executionId)
+	}
+
+	d.SetId(execution.AuthenticatorConfig)
+	d.Set("realm", realm)
+	d.Set("execution_id", executionId)
+
+	err = resourceAuthenticationExecutionConfigRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationExecutionConfigRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	config, err := c.GetAuthenticatorConfig(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("alias", config.Alias)
+	d.Set("config", config.Config)
+
+	return nil
+}
+
+func resourceAuthenticationExecutionConfigCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	created, err := c.CreateAuthenticatorConfig(resourceDataToAuthenticatorConfig(d), d.Get("execution_id").(string), realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAuthenticationExecutionConfigRead(d, m)
+}
+
+// The alias can be changed in place, the execution keeps referring to the config by its ID.
+func resourceAuthenticationExecutionConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	err := c.UpdateAuthenticatorConfig(resourceDataToAuthenticatorConfig(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationExecutionConfigRead(d, m)
+}
+
+func resourceAuthenticationExecutionConfigDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteAuthenticatorConfig(d.Id(), realm(d))
+}
+
+func resourceDataToAuthenticatorConfig(d *schema.ResourceData) *keycloak.AuthenticatorConfig {
+	config := map[string]string{}
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		config[k] = v.(string)
+	}
+
+	return &keycloak.AuthenticatorConfig{
+		Id:     d.Id(),
+		Alias:  d.Get("alias").(string),
+		Config: config,
+	}
+}
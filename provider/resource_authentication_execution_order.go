@@ -0,0 +1,143 @@
+// This file provides a Terraform resource for the order of the executions (and subflows) in an authentication flow.
+// Keycloak only allows moving an execution up or down by one position, so the order is applied step by step.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationExecutionOrder() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationExecutionOrderRead),
+		Create: schema.CreateFunc(resourceAuthenticationExecutionOrderUpdate),
+		Update: schema.UpdateFunc(resourceAuthenticationExecutionOrderUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationExecutionOrderDelete),
+
+		// The order is importable by the flow's alias, but the realm must also be provided by the user. The whole
+		// current order of the flow is imported.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationExecutionOrderHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"parent_flow_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// IDs of executions and of subflow executions (`execution_id`), which are moved to the top of the flow in
+			// this order. Executions not listed here keep their relative order below them.
+			"execution_ids": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// Returns the IDs of the executions directly within the flow (i.e. not within its subflows), in their current order.
+func listAuthenticationExecutionIds(c *keycloak.KeycloakClient, flowAlias, realm string) ([]string, error) {
+	executions, err := c.ListAuthenticationExecutions(flowAlias, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, execution := range executions {
+		if execution.Level == 0 {
+			ids = append(ids, execution.Id)
+		}
+	}
+
+	return ids, nil
+}
+
+func importAuthenticationExecutionOrderHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*keycloak.KeycloakClient)
+
+	realm, alias, err := splitRealmAlias(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := listAuthenticationExecutionIds(c, alias, realm)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(alias)
+	d.Set("realm", realm)
+	d.Set("parent_flow_alias", alias)
+	d.Set("execution_ids", ids)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationExecutionOrderRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	ids, err := listAuthenticationExecutionIds(c, parentFlowAlias(d), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	// Only the top of the flow is managed, anything else showing up there means the order has changed
+	count := len(getStringSlice(d, "execution_ids"))
+	if count > len(ids) {
+		count = len(ids)
+	}
+	d.Set("execution_ids", ids[:count])
+
+	return nil
+}
+
+func resourceAuthenticationExecutionOrderUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	current, err := listAuthenticationExecutionIds(c, parentFlowAlias(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	for i, id := range getStringSlice(d, "execution_ids") {
+		position := -1
+		for j, existing := range current {
+			if existing == id {
+				position = j
+			}
+		}
+		if position < 0 {
+			return fmt.Errorf("Execution %s is not part of flow %s", id, parentFlowAlias(d))
+		}
+
+		for ; position > i; position-- {
+			err = c.RaiseAuthenticationExecutionPriority(id, realm(d))
+			if err != nil {
+				return err
+			}
+			current[position-1], current[position] = current[position], current[position-1]
+		}
+	}
+
+	d.SetId(parentFlowAlias(d))
+
+	return resourceAuthenticationExecutionOrderRead(d, m)
+}
+
+// The executions stay where they are, there is no order to go back to.
+func resourceAuthenticationExecutionOrderDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
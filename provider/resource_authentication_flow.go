@@ -0,0 +1,162 @@
+// This file provides a Terraform resource for top level authentication flows, which can then be bound to a realm or
+// client (e.g. as the browser flow). Their steps are added with the subflow and execution resources.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationFlow() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationFlowRead),
+		Create: schema.CreateFunc(resourceAuthenticationFlowCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationFlowUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationFlowDelete),
+
+		// Flows are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationFlowHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Subflows, executions and bindings refer to the flow by its alias
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// client-flow is for authenticating clients rather than users
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "basic-flow",
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("basic-flow", "client-flow"),
+			},
+			"top_level": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func importAuthenticationFlowHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	err = resourceAuthenticationFlowRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationFlowRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	flow, err := c.GetAuthenticationFlow(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	authenticationFlowToResourceData(flow, d)
+
+	return nil
+}
+
+func resourceAuthenticationFlowCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	flow := resourceDataToAuthenticationFlow(d)
+
+	created, err := c.CreateAuthenticationFlow(flow, realm(d))
+	if err != nil {
+		return err
+	}
+
+	authenticationFlowToResourceData(created, d)
+
+	return nil
+}
+
+func resourceAuthenticationFlowUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	flow := resourceDataToAuthenticationFlow(d)
+
+	err := c.UpdateAuthenticationFlow(flow, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationFlowRead(d, m)
+}
+
+func resourceAuthenticationFlowDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteAuthenticationFlow(d.Id(), realm(d))
+}
+
+func resourceDataToAuthenticationFlow(d *schema.ResourceData) *keycloak.AuthenticationFlow {
+	return &keycloak.AuthenticationFlow{
+		Id:          d.Id(),
+		Alias:       d.Get("alias").(string),
+		Description: d.Get("description").(string),
+		ProviderId:  d.Get("provider_id").(string),
+		TopLevel:    d.Get("top_level").(bool),
+	}
+}
+
+func authenticationFlowToResourceData(flow *keycloak.AuthenticationFlow, d *schema.ResourceData) {
+	d.SetId(flow.Id)
+	d.Set("alias", flow.Alias)
+	d.Set("description", flow.Description)
+	d.Set("provider_id", flow.ProviderId)
+	d.Set("top_level", flow.TopLevel)
+}
+
+// Keycloak accepts realm bindings to flows which don't exist, which breaks logging in to the realm. So bound flow
+// aliases are checked right before binding them (this can't be done when planning, as the flow may only be created by
+// the same apply).
+func validateAuthenticationFlowAliases(c *keycloak.KeycloakClient, realm string, aliases ...string) error {
+	flows, err := c.ListAuthenticationFlows(realm)
+	if err != nil {
+		return err
+	}
+
+	existing := map[string]bool{}
+	for _, flow := range flows {
+		existing[flow.Alias] = true
+	}
+
+	for _, alias := range aliases {
+		if alias != "" && !existing[alias] {
+			return fmt.Errorf("Authentication flow %s does not exist in realm %s", alias, realm)
+		}
+	}
+
+	return nil
+}
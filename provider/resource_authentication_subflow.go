@@ -0,0 +1,205 @@
+// This file provides a Terraform resource for subflows, which group executions (and further subflows) within an
+// authentication flow, e.g. the conditional OTP part of a browser flow.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationSubflow() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationSubflowRead),
+		Create: schema.CreateFunc(resourceAuthenticationSubflowCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationSubflowUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationSubflowDelete),
+
+		CustomizeDiff: validateAuthenticationSubflowAuthenticator,
+
+		// Subflows are importable by ID, but the realm and parent flow alias must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationSubflowHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"parent_flow_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Executions are added to the subflow using this as their parent flow alias
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// form-flow is for forms like the registration page, which are rendered by the authenticator below
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "basic-flow",
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("basic-flow", "form-flow"),
+			},
+			// The form provider of a form-flow, e.g. `registration-page-form`
+			"authenticator": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"requirement": authenticationRequirementSchema(),
+
+			// Computed fields
+			// The ID of the subflow's execution in the parent flow, used for ordering it
+			"execution_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// A form-flow can't work without its form provider, so a missing one is reported when planning.
+func validateAuthenticationSubflowAuthenticator(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("provider_id") || !d.NewValueKnown("authenticator") {
+		return nil
+	}
+
+	if d.Get("provider_id").(string) == "form-flow" && d.Get("authenticator").(string) == "" {
+		return fmt.Errorf("authenticator must be set for form-flow subflows")
+	}
+
+	return nil
+}
+
+func importAuthenticationSubflowHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, alias, id, err := splitRealmFlowAliasId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+	d.Set("parent_flow_alias", alias)
+
+	err = resourceAuthenticationSubflowRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// Returns the execution of the subflow in its parent flow.
+func findAuthenticationSubflowExecution(c *keycloak.KeycloakClient, d *schema.ResourceData) (*keycloak.AuthenticationExecutionInfo, error) {
+	executions, err := c.ListAuthenticationExecutions(parentFlowAlias(d), realm(d))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, execution := range executions {
+		if execution.AuthenticationFlow && execution.FlowId == d.Id() {
+			return execution, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Could not find subflow %s in flow %s", d.Id(), parentFlowAlias(d))
+}
+
+func resourceAuthenticationSubflowRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	flow, err := c.GetAuthenticationFlow(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	execution, err := findAuthenticationSubflowExecution(c, d)
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("alias", flow.Alias)
+	d.Set("description", flow.Description)
+	d.Set("provider_id", flow.ProviderId)
+	d.Set("requirement", execution.Requirement)
+	d.Set("execution_id", execution.Id)
+
+	return nil
+}
+
+func resourceAuthenticationSubflowCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	subflow := &keycloak.AuthenticationFlow{
+		Alias:       d.Get("alias").(string),
+		Description: d.Get("description").(string),
+		ProviderId:  d.Get("provider_id").(string),
+	}
+
+	created, err := c.CreateAuthenticationSubflow(subflow, d.Get("authenticator").(string), parentFlowAlias(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAuthenticationSubflowUpdate(d, m)
+}
+
+func resourceAuthenticationSubflowUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	if d.HasChange("description") && !d.IsNewResource() {
+		flow, err := c.GetAuthenticationFlow(d.Id(), realm(d))
+		if err != nil {
+			return err
+		}
+
+		flow.Description = d.Get("description").(string)
+		err = c.UpdateAuthenticationFlow(flow, realm(d))
+		if err != nil {
+			return err
+		}
+	}
+
+	execution, err := findAuthenticationSubflowExecution(c, d)
+	if err != nil {
+		return err
+	}
+
+	err = updateAuthenticationRequirement(c, execution.Id, parentFlowAlias(d), realm(d), d.Get("requirement").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationSubflowRead(d, m)
+}
+
+// Deleting the subflow's execution deletes the subflow as well.
+func resourceAuthenticationSubflowDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	execution, err := findAuthenticationSubflowExecution(c, d)
+	if err != nil {
+		return err
+	}
+
+	return c.DeleteAuthenticationExecution(execution.Id, realm(d))
+}
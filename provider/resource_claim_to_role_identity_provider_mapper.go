@@ -0,0 +1,48 @@
+// This file provides a Terraform resource for mappers granting a role to users whose token contains a given claim
+// value. Only OpenID Connect identity providers support this mapper.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceClaimToRoleIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: func(_ *schema.ResourceData, providerId string) (string, error) {
+			if !isOidcIdentityProvider(providerId) {
+				return "", fmt.Errorf("Claim to role mappers can only be used with OpenID Connect identity providers, not %s", providerId)
+			}
+			return "oidc-role-idp-mapper", nil
+		},
+		schema: map[string]*schema.Schema{
+			"claim_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Realm roles are given by name, client roles as `${client_id}.${role_name}`
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			return map[string]string{
+				"claim":       d.Get("claim_name").(string),
+				"claim.value": d.Get("claim_value").(string),
+				"role":        d.Get("role").(string),
+			}, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("claim_name", config["claim"])
+			d.Set("claim_value", config["claim.value"])
+			d.Set("role", config["role"])
+		},
+	})
+}
@@ -16,6 +16,8 @@ func resourceClient() *schema.Resource {
 		Update: schema.UpdateFunc(resourceClientUpdate),
 		Delete: schema.DeleteFunc(resourceClientDelete),
 
+		CustomizeDiff: customizeClientAttributesDiff,
+
 		// Keycloak clients are importable by ID, but the realm must also be provided by the user.
 		Importer: &schema.ResourceImporter{
 			State: importClientHelper,
@@ -106,17 +108,57 @@ func resourceClient() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			// Client specific flows (by flow ID), overriding the realm's browser and direct grant flow bindings
+			"authentication_flow_binding_overrides": authenticationFlowBindingOverridesSchema(),
+			// Only the attributes declared here are managed, anything else Keycloak sets is left alone.
 			"attributes": {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
 
-			// Computed fields (i.e. things looked up in Keycloak after client creation)
+			// Keycloak generates a secret if none is given, setting it is mostly useful when migrating existing clients.
 			"client_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"rotate_secret_trigger"},
+			},
+			// Arbitrary values, the client secret is regenerated whenever any of them change.
+			"rotate_secret_trigger": {
+				Type:          schema.TypeMap,
+				Optional:      true,
+				ConflictsWith: []string{"client_secret"},
+			},
+
+			// Computed fields (i.e. things looked up in Keycloak after client creation)
+			// The times are in seconds since the epoch and only set if the realm has a secret rotation policy.
+			"client_secret_creation_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"client_secret_expiration_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"client_secret_rotated": {
 				Type:      schema.TypeString,
 				Computed:  true,
 				Sensitive: true,
 			},
+			"client_secret_rotated_creation_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"client_secret_rotated_expiration_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			// Every attribute of the client, including the ones populated by Keycloak itself
+			"all_attributes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
 			"service_account_user_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -130,6 +172,93 @@ func resourceClient() *schema.Resource {
 	}
 }
 
+const (
+	clientAttributeSecretCreationTime          = "client.secret.creation.time"
+	clientAttributeSecretExpirationTime        = "client.secret.expiration.time"
+	clientAttributeSecretRotated               = "client.secret.rotated"
+	clientAttributeSecretRotatedCreationTime   = "client.secret.rotated.creation.time"
+	clientAttributeSecretRotatedExpirationTime = "client.secret.rotated.expiration.time"
+)
+
+var clientSecretComputedKeys = []string{
+	"client_secret",
+	"client_secret_creation_time",
+	"client_secret_expiration_time",
+	"client_secret_rotated",
+	"client_secret_rotated_creation_time",
+	"client_secret_rotated_expiration_time",
+}
+
+// Changing the managed attributes also changes the full set of attributes on the client.
+func customizeClientAttributesDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if d.HasChange("attributes") {
+		if err := d.SetNewComputed("all_attributes"); err != nil {
+			return err
+		}
+	}
+
+	// Rotating the secret changes it (and the rotated secret, if the realm keeps one) on the next apply
+	if d.HasChange("rotate_secret_trigger") && d.Id() != "" {
+		for _, key := range clientSecretComputedKeys {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func authenticationFlowBindingOverridesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"browser_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"direct_grant_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+// Both overrides are always sent, as Keycloak only removes an override when it is sent with an empty flow ID.
+func getAuthenticationFlowBindingOverrides(d *schema.ResourceData) map[string]string {
+	overrides := map[string]string{
+		"browser":      "",
+		"direct_grant": "",
+	}
+
+	if v, present := d.GetOk("authentication_flow_binding_overrides"); present {
+		block := v.([]interface{})[0].(map[string]interface{})
+		overrides["browser"] = block["browser_id"].(string)
+		overrides["direct_grant"] = block["direct_grant_id"].(string)
+	}
+
+	return overrides
+}
+
+func setAuthenticationFlowBindingOverrides(overrides map[string]string, d *schema.ResourceData) {
+	if overrides["browser"] == "" && overrides["direct_grant"] == "" {
+		d.Set("authentication_flow_binding_overrides", nil)
+		return
+	}
+
+	d.Set("authentication_flow_binding_overrides", []interface{}{
+		map[string]interface{}{
+			"browser_id":      overrides["browser"],
+			"direct_grant_id": overrides["direct_grant"],
+		},
+	})
+}
+
 func importClientHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	realm, id, err := splitRealmId(d.Id())
 	if err != nil {
@@ -203,7 +332,19 @@ func resourceClientCreate(d *schema.ResourceData, m interface{}) error {
 func resourceClientUpdate(d *schema.ResourceData, m interface{}) error {
 	client := resourceDataToClient(d)
 	apiClient := m.(*keycloak.KeycloakClient)
-	return apiClient.UpdateClient(&client, realm(d))
+	err := apiClient.UpdateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("rotate_secret_trigger") {
+		_, err = apiClient.RegenerateClientSecret(d.Id(), realm(d))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceClientRead(d, m)
 }
 
 func resourceClientDelete(d *schema.ResourceData, m interface{}) error {
@@ -228,6 +369,15 @@ func resourceDataToClient(d *schema.ResourceData) keycloak.Client {
 		}
 	}
 
+	// Keycloak keeps attributes which are missing from an update, so attributes which are no longer declared are
+	// explicitly cleared.
+	if d.HasChange("attributes") {
+		oldAttributes, _ := d.GetChange("attributes")
+		for k := range oldAttributes.(map[string]interface{}) {
+			attributes[k] = ""
+		}
+	}
+
 	rawAttributes, present := d.GetOk("attributes")
 	if present {
 		for k, v := range rawAttributes.(map[string]interface{}) {
@@ -236,23 +386,29 @@ func resourceDataToClient(d *schema.ResourceData) keycloak.Client {
 	}
 
 	c := keycloak.Client{
-		ClientId:                  d.Get("client_id").(string),
-		Enabled:                   d.Get("enabled").(bool),
-		ClientAuthenticatorType:   d.Get("client_authenticator_type").(string),
-		RedirectUris:              redirectUris,
-		Protocol:                  d.Get("protocol").(string),
-		PublicClient:              d.Get("public_client").(bool),
-		BearerOnly:                d.Get("bearer_only").(bool),
-		ServiceAccountsEnabled:    d.Get("service_accounts_enabled").(bool),
-		DirectAccessGrantsEnabled: d.Get("direct_access_grants_enabled").(bool),
-		ImplicitFlowEnabled:       d.Get("implicit_flow_enabled").(bool),
-		StandardFlowEnabled:       d.Get("standard_flow_enabled").(bool),
-		WebOrigins:                webOrigins,
-		RootUrl:                   d.Get("root_url").(string),
-		AdminUrl:                  d.Get("admin_url").(string),
-		BaseUrl:                   d.Get("base_url").(string),
-		FullScopeAllowed:          d.Get("full_scope_allowed").(bool),
-		Attributes:                attributes,
+		ClientId:                           d.Get("client_id").(string),
+		Enabled:                            d.Get("enabled").(bool),
+		ClientAuthenticatorType:            d.Get("client_authenticator_type").(string),
+		RedirectUris:                       redirectUris,
+		Protocol:                           d.Get("protocol").(string),
+		PublicClient:                       d.Get("public_client").(bool),
+		BearerOnly:                         d.Get("bearer_only").(bool),
+		ServiceAccountsEnabled:             d.Get("service_accounts_enabled").(bool),
+		DirectAccessGrantsEnabled:          d.Get("direct_access_grants_enabled").(bool),
+		ImplicitFlowEnabled:                d.Get("implicit_flow_enabled").(bool),
+		StandardFlowEnabled:                d.Get("standard_flow_enabled").(bool),
+		WebOrigins:                         webOrigins,
+		RootUrl:                            d.Get("root_url").(string),
+		AdminUrl:                           d.Get("admin_url").(string),
+		BaseUrl:                            d.Get("base_url").(string),
+		AuthenticationFlowBindingOverrides: getAuthenticationFlowBindingOverrides(d),
+		FullScopeAllowed:                   d.Get("full_scope_allowed").(bool),
+		Attributes:                         attributes,
+	}
+
+	// The secret from the state is the one Keycloak already has, only send it when it's actually being changed.
+	if d.HasChange("client_secret") {
+		c.Secret = d.Get("client_secret").(string)
 	}
 
 	if !d.IsNewResource() {
@@ -280,12 +436,30 @@ func clientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
 	d.Set("admin_url", c.AdminUrl)
 	d.Set("base_url", c.BaseUrl)
 	d.Set("full_scope_allowed", c.FullScopeAllowed)
-	d.Set("attributes", c.Attributes)
-}
+	setAuthenticationFlowBindingOverrides(c.AuthenticationFlowBindingOverrides, d)
+	d.Set("client_secret_creation_time", getAttributeInt(c.Attributes, clientAttributeSecretCreationTime))
+	d.Set("client_secret_expiration_time", getAttributeInt(c.Attributes, clientAttributeSecretExpirationTime))
+	d.Set("client_secret_rotated", getAttributeString(c.Attributes, clientAttributeSecretRotated))
+	d.Set("client_secret_rotated_creation_time", getAttributeInt(c.Attributes, clientAttributeSecretRotatedCreationTime))
+	d.Set("client_secret_rotated_expiration_time", getAttributeInt(c.Attributes, clientAttributeSecretRotatedExpirationTime))
+
+	// The rotated secret is exposed as a sensitive field above, so keep it out of the (non-sensitive) attribute map
+	allAttributes := map[string]interface{}{}
+	for k, v := range c.Attributes {
+		if k != clientAttributeSecretRotated {
+			allAttributes[k] = v
+		}
+	}
+	d.Set("all_attributes", allAttributes)
 
-func defaultClientAttributes() map[string]interface{} {
-	return map[string]interface{}{
-		"saml.assertion.signature": true,
-		"xray":                     "foobar",
+	// Only report back the attributes which are declared in the configuration, so that attributes populated by
+	// Keycloak don't show up as drift.
+	attributes := map[string]interface{}{}
+	declared, _ := d.Get("attributes").(map[string]interface{})
+	for k := range declared {
+		if v, present := c.Attributes[k]; present {
+			attributes[k] = v
+		}
 	}
+	d.Set("attributes", attributes)
 }
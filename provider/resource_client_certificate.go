@@ -0,0 +1,299 @@
+// This file provides a Terraform resource for the keys and certificates Keycloak stores for a client, e.g. the
+// `saml.signing` certificate of a SAML client or the `jwt.credential` certificate of a client using `client-jwt`
+// authentication.
+
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+var clientCertificateKeystoreFormats = []string{"JKS", "PKCS12"}
+
+func resourceClientCertificate() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceClientCertificateRead),
+		Create: schema.CreateFunc(resourceClientCertificateCreate),
+		Delete: schema.DeleteFunc(resourceClientCertificateDelete),
+
+		CustomizeDiff: validateClientCertificateUpload,
+
+		// Certificates are importable by client ID and attribute, but the realm must also be provided by the user.
+		// Private keys and keystores can't be imported, as Keycloak doesn't return them.
+		Importer: &schema.ResourceImporter{
+			State: importClientCertificateHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// n.b. this is the ID of the client, not its name
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The attribute prefix the keys are stored under, e.g. `jwt.credential`, `saml.signing` or `saml.encryption`
+			"attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// If no upload is given, Keycloak generates a new key pair and certificate.
+			"upload_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("Certificate PEM", "Public Key PEM", "JSON Web Key Set", "JKS", "PKCS12"),
+			},
+			// Keystores (JKS and PKCS12) are binary, so they must be given base64 encoded (e.g. using `filebase64()`)
+			"upload_content": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"key_alias": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"key_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"store_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			// Download the client's keys as a keystore after generating or uploading them
+			"keystore": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "JKS",
+							ValidateFunc: validateOneOf(clientCertificateKeystoreFormats...),
+						},
+						"key_alias": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"key_password": {
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+						"store_password": {
+							Type:      schema.TypeString,
+							Required:  true,
+							ForceNew:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
+
+			// Computed
+			"certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			// Only known when Keycloak generated the keys, it can't be looked up again afterwards
+			"private_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"keystore_base64": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func importClientCertificateHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Attributes contain dots, so the parts are separated by slashes
+	split := strings.SplitN(d.Id(), "/", 3)
+	if len(split) != 3 {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}/${client_id}/${attribute}' (n.b. client_id is not client name)")
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", split[1], split[2]))
+	d.Set("realm", split[0])
+	d.Set("client_id", split[1])
+	d.Set("attribute", split[2])
+
+	err := resourceClientCertificateRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("Client %s has no %s keys", split[1], split[2])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceClientCertificateRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	cert, err := c.GetClientCertificate(client(d), realm(d), d.Get("attribute").(string))
+	if err != nil || (cert.Certificate == "" && cert.PublicKey == "") {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("certificate", cert.Certificate)
+	d.Set("public_key", cert.PublicKey)
+	d.Set("kid", cert.Kid)
+
+	return nil
+}
+
+func resourceClientCertificateCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	attr := d.Get("attribute").(string)
+
+	var cert *keycloak.ClientCertificate
+	var err error
+	if format, present := d.GetOk("upload_format"); present {
+		var upload *keycloak.ClientCertificateUpload
+		upload, err = resourceDataToClientCertificateUpload(d, format.(string))
+		if err != nil {
+			return err
+		}
+		cert, err = c.UploadClientCertificate(client(d), realm(d), attr, upload)
+	} else {
+		cert, err = c.GenerateClientCertificate(client(d), realm(d), attr)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", client(d), attr))
+	d.Set("private_key", cert.PrivateKey)
+
+	if v, present := d.GetOk("keystore"); present {
+		raw := v.([]interface{})[0].(map[string]interface{})
+		realmCertificate := false
+		keystore, err := c.DownloadClientKeystore(client(d), realm(d), attr, &keycloak.KeyStoreConfig{
+			RealmCertificate: &realmCertificate,
+			Format:           raw["format"].(string),
+			KeyAlias:         raw["key_alias"].(string),
+			KeyPassword:      raw["key_password"].(string),
+			StorePassword:    raw["store_password"].(string),
+		})
+		if err != nil {
+			return err
+		}
+		d.Set("keystore_base64", base64.StdEncoding.EncodeToString(keystore))
+	}
+
+	return resourceClientCertificateRead(d, m)
+}
+
+// Keycloak has no endpoint to remove a client's keys, so clear the attributes they are stored in instead.
+func resourceClientCertificateDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	attr := d.Get("attribute").(string)
+
+	kc, err := c.GetClient(client(d), realm(d))
+	if err != nil {
+		// The client is already gone, and its keys with it
+		return nil
+	}
+
+	if kc.Attributes == nil {
+		kc.Attributes = map[string]interface{}{}
+	}
+	for _, suffix := range []string{"certificate", "private.key", "public.key", "kid"} {
+		kc.Attributes[fmt.Sprintf("%s.%s", attr, suffix)] = ""
+	}
+
+	return c.UpdateClient(kc, realm(d))
+}
+
+func isClientCertificateKeystoreFormat(format string) bool {
+	for _, keystoreFormat := range clientCertificateKeystoreFormats {
+		if format == keystoreFormat {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks the upload when planning, unless its content is only known when applying.
+func validateClientCertificateUpload(d *schema.ResourceDiff, m interface{}) error {
+	format := d.Get("upload_format").(string)
+	if format == "" || !d.NewValueKnown("upload_content") {
+		return nil
+	}
+
+	content := d.Get("upload_content").(string)
+	if content == "" {
+		return fmt.Errorf("upload_content must be set when upload_format is given")
+	}
+
+	if isClientCertificateKeystoreFormat(format) {
+		if _, err := base64.StdEncoding.DecodeString(content); err != nil {
+			return fmt.Errorf("upload_content must be base64 encoded for %s keystores: %s", format, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDataToClientCertificateUpload(d *schema.ResourceData, format string) (*keycloak.ClientCertificateUpload, error) {
+	content := d.Get("upload_content").(string)
+
+	file := []byte(content)
+	if isClientCertificateKeystoreFormat(format) {
+		decoded, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return nil, err
+		}
+		file = decoded
+	}
+
+	return &keycloak.ClientCertificateUpload{
+		Format:        format,
+		KeyAlias:      d.Get("key_alias").(string),
+		KeyPassword:   d.Get("key_password").(string),
+		StorePassword: d.Get("store_password").(string),
+		File:          file,
+	}, nil
+}
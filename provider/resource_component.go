@@ -0,0 +1,399 @@
+// This file provides the parts shared by all resources backed by Keycloak components (user federation, LDAP mappers,
+// etc.). Components of every kind use the same representation and keep their settings in an untyped config map, so each
+// resource only describes its own settings and how they map onto that config.
+// The component resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_componentrepresentation
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+type componentType struct {
+	// The providerId and providerType of the component, e.g. `ldap` and `org.keycloak.storage.UserStorageProvider`.
+	// Generic components leave these empty, they are taken from the `provider_id` and `provider_type` fields instead.
+	providerId   string
+	providerType string
+	// Returns the ID of the component's parent, which is the realm for most components
+	parentId func(d *schema.ResourceData, c *keycloak.KeycloakClient) (string, error)
+	// The field holding the parent's ID for components nested in another component (e.g. LDAP mappers). If the field
+	// is left empty, the parentId function is used instead.
+	parentField string
+	// Take over an existing component with the same name instead of creating a second one, for components Keycloak
+	// creates on its own (e.g. the default LDAP mappers)
+	adoptExisting bool
+	// The component has the untyped `config`, `multivalued_config` and `all_config` fields, which only manage the
+	// declared config keys
+	untypedConfig bool
+	// Schema of the component specific settings, merged with the common component schema
+	schema map[string]*schema.Schema
+	// Turns the component specific settings into the config map
+	getConfig func(d *schema.ResourceData) (keycloak.ComponentConfig, error)
+	// Turns the config map (from a GET) into the component specific settings
+	setConfig func(config keycloak.ComponentConfig, d *schema.ResourceData)
+}
+
+func resourceTypedComponent(t *componentType) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+	if t.untypedConfig {
+		s["config"] = componentConfigSchema()
+		s["multivalued_config"] = componentMultivaluedConfigSchema()
+		s["all_config"] = componentAllConfigSchema()
+	}
+	for k, v := range t.schema {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		// API methods
+		Read: schema.ReadFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceComponentRead(t, d, m)
+		}),
+		Create: schema.CreateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceComponentCreate(t, d, m)
+		}),
+		Update: schema.UpdateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceComponentUpdate(t, d, m)
+		}),
+		Delete: schema.DeleteFunc(resourceComponentDelete),
+
+		CustomizeDiff: t.customizeDiff,
+
+		// Components are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return importComponentHelper(t, d, m)
+			},
+		},
+
+		Schema: s,
+	}
+}
+
+// Changing the declared config keys also changes the full config of the component.
+func (t *componentType) customizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if t.untypedConfig && (d.HasChange("config") || d.HasChange("multivalued_config")) {
+		return d.SetNewComputed("all_config")
+	}
+	return nil
+}
+
+// The parent of realm level components (e.g. user federation providers) is the realm's internal ID.
+func realmParentId(d *schema.ResourceData, c *keycloak.KeycloakClient) (string, error) {
+	r, err := c.GetRealm(realm(d))
+	if err != nil {
+		return "", err
+	}
+	return r.Id, nil
+}
+
+// Returns the providerId of the component, which is only known once imported for generic components.
+func (t *componentType) componentProviderId(d *schema.ResourceData) string {
+	if t.providerId != "" {
+		return t.providerId
+	}
+	return d.Get("provider_id").(string)
+}
+
+func (t *componentType) componentProviderType(d *schema.ResourceData) string {
+	if t.providerType != "" {
+		return t.providerType
+	}
+	return d.Get("provider_type").(string)
+}
+
+func importComponentHelper(t *componentType, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	err = resourceComponentRead(t, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceComponentRead(t *componentType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	component, err := c.GetComponent(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	providerId, providerType := t.componentProviderId(d), t.componentProviderType(d)
+	if (providerId != "" && component.ProviderId != providerId) || (providerType != "" && component.ProviderType != providerType) {
+		return fmt.Errorf("Component %s is a %s %s, not %s %s", component.Id, component.ProviderId, component.ProviderType, providerId, providerType)
+	}
+
+	d.Set("name", component.Name)
+	if t.providerId == "" {
+		d.Set("provider_id", component.ProviderId)
+	}
+	if t.providerType == "" {
+		d.Set("provider_type", component.ProviderType)
+	}
+	if t.parentField != "" {
+		d.Set(t.parentField, component.ParentId)
+	}
+	t.setConfig(component.Config, d)
+
+	return nil
+}
+
+func resourceComponentCreate(t *componentType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	component, err := resourceDataToComponent(t, d, c)
+	if err != nil {
+		return err
+	}
+
+	if t.adoptExisting {
+		existing, err := findComponent(c, realm(d), component)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			component.Id = existing.Id
+			err = c.UpdateComponent(component, realm(d))
+			if err != nil {
+				return err
+			}
+			d.SetId(existing.Id)
+			return resourceComponentRead(t, d, m)
+		}
+	}
+
+	created, err := c.CreateComponent(component, realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceComponentRead(t, d, m)
+}
+
+// Returns the sibling of the component with the same name and provider, or nil if there is none.
+func findComponent(c *keycloak.KeycloakClient, realm string, component *keycloak.Component) (*keycloak.Component, error) {
+	siblings, err := c.ListComponents(realm, component.ParentId, component.ProviderType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sibling := range siblings {
+		if sibling.Name == component.Name && sibling.ProviderId == component.ProviderId {
+			return sibling, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceComponentUpdate(t *componentType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	component, err := resourceDataToComponent(t, d, c)
+	if err != nil {
+		return err
+	}
+
+	if t.untypedConfig {
+		existing, err := c.GetComponent(d.Id(), realm(d))
+		if err != nil {
+			return err
+		}
+		keepUndeclaredComponentConfig(d, existing.Config, component.Config)
+	}
+
+	err = c.UpdateComponent(component, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceComponentRead(t, d, m)
+}
+
+// Only the declared config keys are sent, so the keys Keycloak keeps on its own (e.g. generated keys) are carried over
+// from the existing component. Keys which were declared before are left out, which removes them.
+func keepUndeclaredComponentConfig(d *schema.ResourceData, existing, config keycloak.ComponentConfig) {
+	previouslyDeclared := map[string]bool{}
+	oldConfig, _ := d.GetChange("config")
+	for k := range oldConfig.(map[string]interface{}) {
+		previouslyDeclared[k] = true
+	}
+	oldMultivalued, _ := d.GetChange("multivalued_config")
+	for _, v := range oldMultivalued.(*schema.Set).List() {
+		previouslyDeclared[v.(map[string]interface{})["key"].(string)] = true
+	}
+
+	for k, values := range existing {
+		if _, declared := config[k]; !declared && !previouslyDeclared[k] {
+			config[k] = values
+		}
+	}
+}
+
+func resourceComponentDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteComponent(d.Id(), realm(d))
+}
+
+func resourceDataToComponent(t *componentType, d *schema.ResourceData, c *keycloak.KeycloakClient) (*keycloak.Component, error) {
+	var parentId string
+	if t.parentField != "" {
+		parentId = d.Get(t.parentField).(string)
+	}
+	if parentId == "" {
+		var err error
+		parentId, err = t.parentId(d, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := t.getConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
+	component := keycloak.Component{
+		Name:         d.Get("name").(string),
+		ProviderId:   t.componentProviderId(d),
+		ProviderType: t.componentProviderType(d),
+		ParentId:     parentId,
+		Config:       config,
+	}
+
+	if !d.IsNewResource() {
+		component.Id = d.Id()
+	}
+
+	return &component, nil
+}
+
+// Schema of the untyped config of generic components. Most config keys only hold a single value, which is given here.
+func componentConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+	}
+}
+
+// Schema of the config keys holding more than one value (e.g. a list of hosts).
+func componentMultivaluedConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// Adds the `config` and `multivalued_config` fields to the config.
+func getComponentConfigFields(d *schema.ResourceData, config keycloak.ComponentConfig) {
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		config.Set(k, v.(string))
+	}
+
+	for _, v := range d.Get("multivalued_config").(*schema.Set).List() {
+		multivalued := v.(map[string]interface{})
+		values := []string{}
+		for _, value := range multivalued["values"].([]interface{}) {
+			values = append(values, value.(string))
+		}
+		config[multivalued["key"].(string)] = values
+	}
+}
+
+// Every config key of the component, including the ones Keycloak populates itself. Keys with several values are joined
+// with commas.
+func componentAllConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+	}
+}
+
+// Sets the `config` and `multivalued_config` fields from the config. Only the keys declared in them are reported back,
+// so that keys populated by Keycloak don't show up as drift, every other key (except the excluded ones, which have
+// fields of their own) goes into `all_config`.
+func setComponentConfigFields(config keycloak.ComponentConfig, d *schema.ResourceData, excluded ...string) {
+	declared, _ := d.Get("config").(map[string]interface{})
+	declaredMultivalued := map[string]bool{}
+	for _, v := range d.Get("multivalued_config").(*schema.Set).List() {
+		declaredMultivalued[v.(map[string]interface{})["key"].(string)] = true
+	}
+
+	isExcluded := map[string]bool{}
+	for _, k := range excluded {
+		isExcluded[k] = true
+	}
+
+	all := map[string]interface{}{}
+	single := map[string]interface{}{}
+	multivalued := []interface{}{}
+	for k, values := range config {
+		if isExcluded[k] {
+			continue
+		}
+		all[k] = strings.Join(values, ",")
+
+		if declaredMultivalued[k] {
+			untyped := []interface{}{}
+			for _, value := range values {
+				untyped = append(untyped, value)
+			}
+			multivalued = append(multivalued, map[string]interface{}{
+				"key":    k,
+				"values": untyped,
+			})
+			continue
+		}
+
+		if declaredValue, present := declared[k]; present {
+			// Secrets come back masked, so the configured value is kept
+			if config.Get(k) == keycloak.ComponentSecretValue {
+				single[k] = declaredValue
+			} else {
+				single[k] = config.Get(k)
+			}
+		}
+	}
+
+	d.Set("config", single)
+	d.Set("multivalued_config", multivalued)
+	d.Set("all_config", all)
+}
@@ -0,0 +1,38 @@
+// This file provides a Terraform resource for user federation providers implemented by a custom user storage SPI.
+// Their settings are unknown to the provider, so they are given as an untyped config.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceCustomUserFederation() *schema.Resource {
+	return resourceTypedComponent(&componentType{
+		providerType:  userStorageProviderType,
+		parentId:      realmParentId,
+		untypedConfig: true,
+		getConfig:     getCustomUserFederationConfig,
+		setConfig:     setCustomUserFederationConfig,
+		schema: userFederationSchema(map[string]*schema.Schema{
+			// The ID the custom provider's factory registers itself with
+			"provider_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	})
+}
+
+func getCustomUserFederationConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := getUserFederationConfig(d)
+	getComponentConfigFields(d, config)
+	return config, nil
+}
+
+func setCustomUserFederationConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setUserFederationConfig(config, d)
+	setComponentConfigFields(config, d, userFederationConfigKeys...)
+}
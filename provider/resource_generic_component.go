@@ -0,0 +1,46 @@
+// This file provides a Terraform resource for any kind of component, for the Keycloak features (key providers, client
+// registration policies, custom SPIs, etc.) which don't have a typed resource.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceComponent() *schema.Resource {
+	return resourceTypedComponent(&componentType{
+		parentId:      realmParentId,
+		parentField:   "parent_id",
+		untypedConfig: true,
+		getConfig: func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+			config := keycloak.ComponentConfig{}
+			getComponentConfigFields(d, config)
+			return config, nil
+		},
+		setConfig: func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+			setComponentConfigFields(config, d)
+		},
+		schema: map[string]*schema.Schema{
+			// e.g. `rsa-generated`
+			"provider_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// e.g. `org.keycloak.keys.KeyProvider`
+			"provider_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Defaults to the realm's internal ID, which is the parent of most components
+			"parent_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	})
+}
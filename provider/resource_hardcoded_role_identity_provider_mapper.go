@@ -0,0 +1,31 @@
+// This file provides a Terraform resource for mappers granting a role to every user logging in through an identity
+// provider.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceHardcodedRoleIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: func(_ *schema.ResourceData, _ string) (string, error) {
+			return "hardcoded-role-idp-mapper", nil
+		},
+		schema: map[string]*schema.Schema{
+			// Realm roles are given by name, client roles as `${client_id}.${role_name}`
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			return map[string]string{
+				"role": d.Get("role").(string),
+			}, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("role", config["role"])
+		},
+	})
+}
@@ -0,0 +1,264 @@
+// This file provides the parts shared by all identity provider resources. Keycloak uses the same representation for
+// every kind of identity provider and keeps the provider specific settings in an untyped config map, so each resource
+// only describes its own settings and how they map onto that config.
+// The identity provider resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_identityproviderrepresentation
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+type identityProviderType struct {
+	// The providerId Keycloak uses for this kind of identity provider, e.g. `oidc` or `saml`
+	providerId string
+	// Schema of the provider specific settings, merged with the common identity provider schema
+	schema map[string]*schema.Schema
+	// Turns the provider specific settings into the config map
+	getConfig func(d *schema.ResourceData, c *keycloak.KeycloakClient) (map[string]string, error)
+	// Turns the config map (from a GET) into the provider specific settings
+	setConfig func(config map[string]string, d *schema.ResourceData)
+	// Checks the provider specific settings when planning, optional
+	customizeDiff schema.CustomizeDiffFunc
+}
+
+func resourceIdentityProvider(t *identityProviderType) *schema.Resource {
+	s := identityProviderSchema()
+	for k, v := range t.schema {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		// API methods
+		Read: schema.ReadFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderRead(t, d, m)
+		}),
+		Create: schema.CreateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderCreate(t, d, m)
+		}),
+		Update: schema.UpdateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderUpdate(t, d, m)
+		}),
+		Delete: schema.DeleteFunc(resourceIdentityProviderDelete),
+
+		CustomizeDiff: t.customizeDiff,
+
+		// Identity providers are importable by alias, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return importIdentityProviderHelper(t, d, m)
+			},
+		},
+
+		Schema: s,
+	}
+}
+
+func identityProviderSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"alias": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"trust_email": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"store_token": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"add_read_token_role_on_create": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"link_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"hide_on_login_page": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"sync_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "IMPORT",
+			ValidateFunc: validateOneOf("IMPORT", "LEGACY", "FORCE"),
+		},
+		"first_broker_login_flow_alias": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "first broker login",
+		},
+		// Runs after every login through the identity provider, e.g. to require OTP on top of the external login
+		"post_broker_login_flow_alias": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+
+		// Computed
+		"internal_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func importIdentityProviderHelper(t *identityProviderType, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, alias, err := splitRealmAlias(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(alias)
+	d.Set("realm", realm)
+
+	err = resourceIdentityProviderRead(t, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIdentityProviderRead(t *identityProviderType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	idp, err := c.GetIdentityProvider(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	if idp.ProviderId != t.providerId {
+		return fmt.Errorf("Identity provider %s is a %s provider, not %s", idp.Alias, idp.ProviderId, t.providerId)
+	}
+
+	identityProviderToResourceData(idp, d)
+	t.setConfig(idp.Config, d)
+
+	return nil
+}
+
+func resourceIdentityProviderCreate(t *identityProviderType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	idp, err := resourceDataToIdentityProvider(t, d, c)
+	if err != nil {
+		return err
+	}
+
+	created, err := c.CreateIdentityProvider(idp, realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Alias)
+
+	return resourceIdentityProviderRead(t, d, m)
+}
+
+func resourceIdentityProviderUpdate(t *identityProviderType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	idp, err := resourceDataToIdentityProvider(t, d, c)
+	if err != nil {
+		return err
+	}
+
+	err = c.UpdateIdentityProvider(idp, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceIdentityProviderRead(t, d, m)
+}
+
+func resourceIdentityProviderDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteIdentityProvider(d.Id(), realm(d))
+}
+
+func resourceDataToIdentityProvider(t *identityProviderType, d *schema.ResourceData, c *keycloak.KeycloakClient) (*keycloak.IdentityProvider, error) {
+	err := validateAuthenticationFlowAliases(c, realm(d), d.Get("first_broker_login_flow_alias").(string),
+		d.Get("post_broker_login_flow_alias").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := t.getConfig(d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	config["hideOnLoginPage"] = fmt.Sprintf("%t", d.Get("hide_on_login_page").(bool))
+	config["syncMode"] = d.Get("sync_mode").(string)
+
+	idp := keycloak.IdentityProvider{
+		Alias:                     d.Get("alias").(string),
+		DisplayName:               d.Get("display_name").(string),
+		ProviderId:                t.providerId,
+		Enabled:                   d.Get("enabled").(bool),
+		TrustEmail:                d.Get("trust_email").(bool),
+		StoreToken:                d.Get("store_token").(bool),
+		AddReadTokenRoleOnCreate:  d.Get("add_read_token_role_on_create").(bool),
+		LinkOnly:                  d.Get("link_only").(bool),
+		FirstBrokerLoginFlowAlias: d.Get("first_broker_login_flow_alias").(string),
+		PostBrokerLoginFlowAlias:  d.Get("post_broker_login_flow_alias").(string),
+		Config:                    config,
+	}
+
+	if !d.IsNewResource() {
+		idp.InternalId = d.Get("internal_id").(string)
+	}
+
+	return &idp, nil
+}
+
+func identityProviderToResourceData(idp *keycloak.IdentityProvider, d *schema.ResourceData) {
+	d.Set("alias", idp.Alias)
+	d.Set("display_name", idp.DisplayName)
+	d.Set("enabled", idp.Enabled)
+	d.Set("trust_email", idp.TrustEmail)
+	d.Set("store_token", idp.StoreToken)
+	d.Set("add_read_token_role_on_create", idp.AddReadTokenRoleOnCreate)
+	d.Set("link_only", idp.LinkOnly)
+	d.Set("first_broker_login_flow_alias", idp.FirstBrokerLoginFlowAlias)
+	d.Set("post_broker_login_flow_alias", idp.PostBrokerLoginFlowAlias)
+	d.Set("internal_id", idp.InternalId)
+	d.Set("hide_on_login_page", idp.Config["hideOnLoginPage"] == "true")
+
+	if syncMode, present := idp.Config["syncMode"]; present {
+		d.Set("sync_mode", syncMode)
+	}
+}
+
+// Keycloak masks secrets in config maps, so only the secret from the configuration (or state) is known.
+func setConfigSecret(d *schema.ResourceData, key, value string) {
+	if value != keycloak.ComponentSecretValue {
+		d.Set(key, value)
+	}
+}
@@ -0,0 +1,243 @@
+// This file provides the generic identity provider mapper resource, as well as the parts shared with the typed mapper
+// resources. Like identity providers, mappers keep their type specific settings in an untyped config map.
+// The mapper resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_identityprovidermapperrepresentation
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+type identityProviderMapperType struct {
+	// Returns the mapper type for the given kind of identity provider (e.g. `oidc`), as some mappers come in a
+	// different flavour for each kind of identity provider.
+	mapperType func(d *schema.ResourceData, providerId string) (string, error)
+	// Schema of the type specific settings, merged with the common mapper schema
+	schema map[string]*schema.Schema
+	// Turns the type specific settings into the config map
+	getConfig func(d *schema.ResourceData) (map[string]string, error)
+	// Turns the config map (from a GET) into the type specific settings
+	setConfig func(config map[string]string, d *schema.ResourceData)
+}
+
+func resourceIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: func(d *schema.ResourceData, _ string) (string, error) {
+			return d.Get("identity_provider_mapper").(string), nil
+		},
+		schema: map[string]*schema.Schema{
+			"identity_provider_mapper": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			config := map[string]string{}
+			for k, v := range d.Get("config").(map[string]interface{}) {
+				config[k] = v.(string)
+			}
+			return config, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			// syncMode has its own field
+			filtered := map[string]string{}
+			for k, v := range config {
+				if k != "syncMode" {
+					filtered[k] = v
+				}
+			}
+			d.Set("config", filtered)
+		},
+	})
+}
+
+func resourceTypedIdentityProviderMapper(t *identityProviderMapperType) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"identity_provider_alias": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		// INHERIT uses the sync mode of the identity provider
+		"sync_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "INHERIT",
+			ValidateFunc: validateOneOf("INHERIT", "IMPORT", "LEGACY", "FORCE"),
+		},
+	}
+	for k, v := range t.schema {
+		s[k] = v
+	}
+
+	// The typed mappers expose which mapper type was picked for the identity provider
+	if _, present := s["identity_provider_mapper"]; !present {
+		s["identity_provider_mapper"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+
+	return &schema.Resource{
+		// API methods
+		Read: schema.ReadFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperRead(t, d, m)
+		}),
+		Create: schema.CreateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperCreate(t, d, m)
+		}),
+		Update: schema.UpdateFunc(func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperUpdate(t, d, m)
+		}),
+		Delete: schema.DeleteFunc(resourceIdentityProviderMapperDelete),
+
+		// Mappers are importable by ID, but the realm and identity provider alias must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				return importIdentityProviderMapperHelper(t, d, m)
+			},
+		},
+
+		Schema: s,
+	}
+}
+
+func identityProviderAlias(d *schema.ResourceData) string {
+	return d.Get("identity_provider_alias").(string)
+}
+
+func importIdentityProviderMapperHelper(t *identityProviderMapperType, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	split := strings.Split(d.Id(), "/")
+	if len(split) != 3 {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}/${identity_provider_alias}/${mapper_id}'")
+	}
+
+	d.SetId(split[2])
+	d.Set("realm", split[0])
+	d.Set("identity_provider_alias", split[1])
+
+	err := resourceIdentityProviderMapperRead(t, d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIdentityProviderMapperRead(t *identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	mapper, err := c.GetIdentityProviderMapper(d.Id(), realm(d), identityProviderAlias(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", mapper.Name)
+	d.Set("identity_provider_alias", mapper.IdentityProviderAlias)
+	d.Set("identity_provider_mapper", mapper.IdentityProviderMapper)
+
+	if syncMode, present := mapper.Config["syncMode"]; present {
+		d.Set("sync_mode", syncMode)
+	}
+
+	t.setConfig(mapper.Config, d)
+
+	return nil
+}
+
+func resourceIdentityProviderMapperCreate(t *identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	idp, err := c.GetIdentityProvider(identityProviderAlias(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	mapperType, err := t.mapperType(d, idp.ProviderId)
+	if err != nil {
+		return err
+	}
+
+	mapper, err := resourceDataToIdentityProviderMapper(t, d, mapperType)
+	if err != nil {
+		return err
+	}
+
+	created, err := c.CreateIdentityProviderMapper(mapper, realm(d), identityProviderAlias(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceIdentityProviderMapperRead(t, d, m)
+}
+
+func resourceIdentityProviderMapperUpdate(t *identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	mapper, err := resourceDataToIdentityProviderMapper(t, d, d.Get("identity_provider_mapper").(string))
+	if err != nil {
+		return err
+	}
+
+	err = c.UpdateIdentityProviderMapper(mapper, realm(d), identityProviderAlias(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceIdentityProviderMapperRead(t, d, m)
+}
+
+func resourceIdentityProviderMapperDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	return c.DeleteIdentityProviderMapper(d.Id(), realm(d), identityProviderAlias(d))
+}
+
+func resourceDataToIdentityProviderMapper(t *identityProviderMapperType, d *schema.ResourceData, mapperType string) (*keycloak.IdentityProviderMapper, error) {
+	config, err := t.getConfig(d)
+	if err != nil {
+		return nil, err
+	}
+
+	config["syncMode"] = d.Get("sync_mode").(string)
+
+	mapper := keycloak.IdentityProviderMapper{
+		Name:                   d.Get("name").(string),
+		IdentityProviderAlias:  identityProviderAlias(d),
+		IdentityProviderMapper: mapperType,
+		Config:                 config,
+	}
+
+	if !d.IsNewResource() {
+		mapper.Id = d.Id()
+	}
+
+	return &mapper, nil
+}
+
+// Most mappers only exist for OpenID Connect based identity providers.
+func isOidcIdentityProvider(providerId string) bool {
+	return providerId == "oidc" || providerId == "keycloak-oidc"
+}
@@ -0,0 +1,86 @@
+// This file provides a Terraform resource for Kerberos user federation providers, which authenticate users through
+// SPNEGO (and optionally their Kerberos password) without an LDAP server behind them.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKerberosUserFederation() *schema.Resource {
+	return resourceTypedComponent(&componentType{
+		providerId:   "kerberos",
+		providerType: userStorageProviderType,
+		parentId:     realmParentId,
+		getConfig:    getKerberosUserFederationConfig,
+		setConfig:    setKerberosUserFederationConfig,
+		schema: userFederationSchema(map[string]*schema.Schema{
+			"kerberos_realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// e.g. `HTTP/keycloak.example.com@EXAMPLE.COM`
+			"server_principal": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Path of the keytab file on the Keycloak server
+			"key_tab": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"debug": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Lets users log in with their Kerberos username and password on the login page as well
+			"allow_password_authentication": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Only used with password authentication, UNSYNCED lets users change their password in Keycloak
+			"edit_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "READ_ONLY",
+				ValidateFunc: validateOneOf("READ_ONLY", "UNSYNCED"),
+			},
+			"update_profile_first_login": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		}),
+	})
+}
+
+func getKerberosUserFederationConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := getUserFederationConfig(d)
+
+	config.Set("kerberosRealm", d.Get("kerberos_realm").(string))
+	config.Set("serverPrincipal", d.Get("server_principal").(string))
+	config.Set("keyTab", d.Get("key_tab").(string))
+	config.SetBool("debug", d.Get("debug").(bool))
+	config.SetBool("allowPasswordAuthentication", d.Get("allow_password_authentication").(bool))
+	config.Set("editMode", d.Get("edit_mode").(string))
+	config.SetBool("updateProfileFirstLogin", d.Get("update_profile_first_login").(bool))
+
+	return config, nil
+}
+
+func setKerberosUserFederationConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setUserFederationConfig(config, d)
+
+	d.Set("kerberos_realm", config.Get("kerberosRealm"))
+	d.Set("server_principal", config.Get("serverPrincipal"))
+	d.Set("key_tab", config.Get("keyTab"))
+	d.Set("debug", config.GetBool("debug"))
+	d.Set("allow_password_authentication", config.GetBool("allowPasswordAuthentication"))
+	if editMode := config.Get("editMode"); editMode != "" {
+		d.Set("edit_mode", editMode)
+	}
+	d.Set("update_profile_first_login", config.GetBool("updateProfileFirstLogin"))
+}
@@ -0,0 +1,33 @@
+// This file provides a Terraform resource for LDAP mappers syncing a user's X.509 certificate, which is used by the
+// X.509 client certificate authenticator.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapCertificateMapper() *schema.Resource {
+	s := ldapUserAttributeMapperSchema()
+	// Whether LDAP stores the certificate DER encoded rather than PEM encoded
+	s["is_der_formatted"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+
+	return resourceLdapMapper("certificate-ldap-mapper", s,
+		func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+			config, err := getLdapUserAttributeMapperConfig(d)
+			if err != nil {
+				return nil, err
+			}
+			config.SetBool("is.der.formatted", d.Get("is_der_formatted").(bool))
+			return config, nil
+		},
+		func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+			setLdapUserAttributeMapperConfig(config, d)
+			d.Set("is_der_formatted", config.GetBool("is.der.formatted"))
+		})
+}
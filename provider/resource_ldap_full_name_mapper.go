@@ -0,0 +1,41 @@
+// This file provides a Terraform resource for LDAP mappers syncing a single LDAP attribute (e.g. `cn`) with the user's
+// first and last name.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapFullNameMapper() *schema.Resource {
+	return resourceLdapMapper("full-name-ldap-mapper", map[string]*schema.Schema{
+		"ldap_full_name_attribute": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"read_only": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"write_only"},
+		},
+		// Only writes the name to LDAP, for when the first and last name are imported by other mappers
+		"write_only": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			Default:       false,
+			ConflictsWith: []string{"read_only"},
+		},
+	}, func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+		config := keycloak.ComponentConfig{}
+		config.Set("ldap.full.name.attribute", d.Get("ldap_full_name_attribute").(string))
+		config.SetBool("read.only", d.Get("read_only").(bool))
+		config.SetBool("write.only", d.Get("write_only").(bool))
+		return config, nil
+	}, func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+		d.Set("ldap_full_name_attribute", config.Get("ldap.full.name.attribute"))
+		d.Set("read_only", config.GetBool("read.only"))
+		d.Set("write_only", config.GetBool("write.only"))
+	})
+}
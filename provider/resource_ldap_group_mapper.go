@@ -0,0 +1,91 @@
+// This file provides a Terraform resource for LDAP mappers syncing LDAP groups (and the users' memberships) with
+// Keycloak groups.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapGroupMapper() *schema.Resource {
+	return resourceLdapMapper("group-ldap-mapper", ldapMembershipMapperSchema(map[string]*schema.Schema{
+		"ldap_groups_dn": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"group_name_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "cn",
+		},
+		"group_object_classes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"groups_ldap_filter": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateLdapFilter,
+		},
+		"preserve_group_inheritance": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"ignore_missing_groups": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		// LDAP attributes of the group entries which are synced with group attributes of the same name
+		"mapped_group_attributes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"drop_non_existing_groups_during_sync": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}), getLdapGroupMapperConfig, setLdapGroupMapperConfig)
+}
+
+func getLdapGroupMapperConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+	getLdapMembershipMapperConfig(d, config)
+
+	objectClasses := getStringSlice(d, "group_object_classes")
+	if len(objectClasses) == 0 {
+		objectClasses = []string{"groupOfNames"}
+	}
+
+	config.Set("groups.dn", d.Get("ldap_groups_dn").(string))
+	config.Set("group.name.ldap.attribute", d.Get("group_name_ldap_attribute").(string))
+	config.Set("group.object.classes", strings.Join(objectClasses, ", "))
+	config.Set("groups.ldap.filter", d.Get("groups_ldap_filter").(string))
+	config.SetBool("preserve.group.inheritance", d.Get("preserve_group_inheritance").(bool))
+	config.SetBool("ignore.missing.groups", d.Get("ignore_missing_groups").(bool))
+	config.Set("mapped.group.attributes", strings.Join(getStringSlice(d, "mapped_group_attributes"), ","))
+	config.SetBool("drop.non.existing.groups.during.sync", d.Get("drop_non_existing_groups_during_sync").(bool))
+
+	return config, nil
+}
+
+func setLdapGroupMapperConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setLdapMembershipMapperConfig(config, d)
+
+	d.Set("ldap_groups_dn", config.Get("groups.dn"))
+	d.Set("group_name_ldap_attribute", config.Get("group.name.ldap.attribute"))
+	d.Set("group_object_classes", splitCommaList(config.Get("group.object.classes")))
+	d.Set("groups_ldap_filter", config.Get("groups.ldap.filter"))
+	d.Set("preserve_group_inheritance", config.GetBool("preserve.group.inheritance"))
+	d.Set("ignore_missing_groups", config.GetBool("ignore.missing.groups"))
+	d.Set("mapped_group_attributes", splitCommaList(config.Get("mapped.group.attributes")))
+	d.Set("drop_non_existing_groups_during_sync", config.GetBool("drop.non.existing.groups.during.sync"))
+}
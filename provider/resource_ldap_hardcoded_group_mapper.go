@@ -0,0 +1,24 @@
+// This file provides a Terraform resource for LDAP mappers adding every user imported from LDAP to a group.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapHardcodedGroupMapper() *schema.Resource {
+	return resourceLdapMapper("hardcoded-ldap-group-mapper", map[string]*schema.Schema{
+		// The group's path, e.g. `/parent/child`
+		"group": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}, func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+		config := keycloak.ComponentConfig{}
+		config.Set("group", d.Get("group").(string))
+		return config, nil
+	}, func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+		d.Set("group", config.Get("group"))
+	})
+}
@@ -0,0 +1,24 @@
+// This file provides a Terraform resource for LDAP mappers granting a role to every user imported from LDAP.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapHardcodedRoleMapper() *schema.Resource {
+	return resourceLdapMapper("hardcoded-ldap-role-mapper", map[string]*schema.Schema{
+		// Realm roles are given by name, client roles as `${client_id}.${role_name}`
+		"role": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}, func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+		config := keycloak.ComponentConfig{}
+		config.Set("role", d.Get("role").(string))
+		return config, nil
+	}, func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+		d.Set("role", config.Get("role"))
+	})
+}
@@ -0,0 +1,114 @@
+// This file provides the parts shared by the LDAP mapper resources. LDAP mappers are components nested in an LDAP user
+// federation provider, which sync a part of the user (an attribute, group memberships, etc.) with LDAP.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+const ldapMapperProviderType = "org.keycloak.storage.ldap.mappers.LDAPStorageMapper"
+
+func resourceLdapMapper(providerId string, s map[string]*schema.Schema,
+	getConfig func(d *schema.ResourceData) (keycloak.ComponentConfig, error),
+	setConfig func(config keycloak.ComponentConfig, d *schema.ResourceData)) *schema.Resource {
+
+	s["ldap_user_federation_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	return resourceTypedComponent(&componentType{
+		providerId:   providerId,
+		providerType: ldapMapperProviderType,
+		parentField:  "ldap_user_federation_id",
+		// Keycloak creates default mappers along with the LDAP provider, a mapper with the same name replaces these
+		adoptExisting: true,
+		schema:        s,
+		getConfig:     getConfig,
+		setConfig:     setConfig,
+	})
+}
+
+// Deletes the mappers Keycloak created along with an LDAP provider.
+func deleteDefaultLdapMappers(c *keycloak.KeycloakClient, realm, ldapId string) error {
+	mappers, err := c.ListComponents(realm, ldapId, ldapMapperProviderType)
+	if err != nil {
+		return err
+	}
+
+	for _, mapper := range mappers {
+		err = c.DeleteComponent(mapper.Id, realm)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Schema shared by the group and role mappers, which both sync memberships of LDAP entries.
+func ldapMembershipMapperSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	membership := map[string]*schema.Schema{
+		// LDAP_ONLY keeps the memberships in LDAP only, IMPORT only imports them and never writes them back
+		"mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "READ_ONLY",
+			ValidateFunc: validateOneOf("READ_ONLY", "LDAP_ONLY", "IMPORT"),
+		},
+		"membership_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "member",
+		},
+		"membership_attribute_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "DN",
+			ValidateFunc: validateOneOf("DN", "UID"),
+		},
+		"membership_user_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "uid",
+		},
+		"user_roles_retrieve_strategy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "LOAD_GROUPS_BY_MEMBER_ATTRIBUTE",
+			ValidateFunc: validateOneOf("LOAD_GROUPS_BY_MEMBER_ATTRIBUTE", "GET_GROUPS_FROM_USER_MEMBEROF_ATTRIBUTE",
+				"LOAD_GROUPS_BY_MEMBER_ATTRIBUTE_RECURSIVELY"),
+		},
+		// Only used by GET_GROUPS_FROM_USER_MEMBEROF_ATTRIBUTE
+		"memberof_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "memberOf",
+		},
+	}
+	for k, v := range s {
+		membership[k] = v
+	}
+	return membership
+}
+
+func getLdapMembershipMapperConfig(d *schema.ResourceData, config keycloak.ComponentConfig) {
+	config.Set("mode", d.Get("mode").(string))
+	config.Set("membership.ldap.attribute", d.Get("membership_ldap_attribute").(string))
+	config.Set("membership.attribute.type", d.Get("membership_attribute_type").(string))
+	config.Set("membership.user.ldap.attribute", d.Get("membership_user_ldap_attribute").(string))
+	config.Set("user.roles.retrieve.strategy", d.Get("user_roles_retrieve_strategy").(string))
+	config.Set("memberof.ldap.attribute", d.Get("memberof_ldap_attribute").(string))
+}
+
+func setLdapMembershipMapperConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	d.Set("mode", config.Get("mode"))
+	d.Set("membership_ldap_attribute", config.Get("membership.ldap.attribute"))
+	d.Set("membership_attribute_type", config.Get("membership.attribute.type"))
+	d.Set("membership_user_ldap_attribute", config.Get("membership.user.ldap.attribute"))
+	d.Set("user_roles_retrieve_strategy", config.Get("user.roles.retrieve.strategy"))
+	d.Set("memberof_ldap_attribute", config.Get("memberof.ldap.attribute"))
+}
@@ -0,0 +1,26 @@
+// This file provides a Terraform resource for LDAP mappers syncing the state of Active Directory accounts (disabled,
+// password expired, etc.) through the `userAccountControl` attribute.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapMsadUserAccountControlMapper() *schema.Resource {
+	return resourceLdapMapper("msad-user-account-control-mapper", map[string]*schema.Schema{
+		// Shows the reason a password was rejected by the AD password policy instead of a generic error
+		"ldap_password_policy_hints_enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}, func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+		config := keycloak.ComponentConfig{}
+		config.SetBool("ldap.password.policy.hints.enabled", d.Get("ldap_password_policy_hints_enabled").(bool))
+		return config, nil
+	}, func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+		d.Set("ldap_password_policy_hints_enabled", config.GetBool("ldap.password.policy.hints.enabled"))
+	})
+}
@@ -0,0 +1,76 @@
+// This file provides a Terraform resource for LDAP mappers syncing LDAP groups (and the users' memberships) with realm
+// or client roles.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapRoleMapper() *schema.Resource {
+	return resourceLdapMapper("role-ldap-mapper", ldapMembershipMapperSchema(map[string]*schema.Schema{
+		"ldap_roles_dn": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"role_name_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "cn",
+		},
+		"role_object_classes": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"roles_ldap_filter": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateLdapFilter,
+		},
+		// The roles are mapped to realm roles, unless a client is given (by its client_id, not its ID)
+		"client_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}), getLdapRoleMapperConfig, setLdapRoleMapperConfig)
+}
+
+func getLdapRoleMapperConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+	getLdapMembershipMapperConfig(d, config)
+
+	objectClasses := getStringSlice(d, "role_object_classes")
+	if len(objectClasses) == 0 {
+		objectClasses = []string{"groupOfNames"}
+	}
+
+	clientId := d.Get("client_id").(string)
+
+	config.Set("roles.dn", d.Get("ldap_roles_dn").(string))
+	config.Set("role.name.ldap.attribute", d.Get("role_name_ldap_attribute").(string))
+	config.Set("role.object.classes", strings.Join(objectClasses, ", "))
+	config.Set("roles.ldap.filter", d.Get("roles_ldap_filter").(string))
+	config.SetBool("use.realm.roles.mapping", clientId == "")
+	config.Set("client.id", clientId)
+
+	return config, nil
+}
+
+func setLdapRoleMapperConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setLdapMembershipMapperConfig(config, d)
+
+	d.Set("ldap_roles_dn", config.Get("roles.dn"))
+	d.Set("role_name_ldap_attribute", config.Get("role.name.ldap.attribute"))
+	d.Set("role_object_classes", splitCommaList(config.Get("role.object.classes")))
+	d.Set("roles_ldap_filter", config.Get("roles.ldap.filter"))
+	if config.GetBool("use.realm.roles.mapping") {
+		d.Set("client_id", "")
+	} else {
+		d.Set("client_id", config.Get("client.id"))
+	}
+}
@@ -0,0 +1,77 @@
+// This file provides a Terraform resource for LDAP mappers syncing an LDAP attribute with a user attribute (or one of
+// the user's properties, like `email`).
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceLdapUserAttributeMapper() *schema.Resource {
+	return resourceLdapMapper("user-attribute-ldap-mapper", ldapUserAttributeMapperSchema(),
+		getLdapUserAttributeMapperConfig, setLdapUserAttributeMapperConfig)
+}
+
+// The certificate mapper extends this mapper, so the schema is shared.
+func ldapUserAttributeMapperSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_model_attribute": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"ldap_attribute": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"read_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"always_read_value_from_ldap": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"is_mandatory_in_ldap": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		// Written to LDAP when the user has no value, for attributes LDAP requires
+		"attribute_default_value": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"is_binary_attribute": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func getLdapUserAttributeMapperConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+
+	config.Set("user.model.attribute", d.Get("user_model_attribute").(string))
+	config.Set("ldap.attribute", d.Get("ldap_attribute").(string))
+	config.SetBool("read.only", d.Get("read_only").(bool))
+	config.SetBool("always.read.value.from.ldap", d.Get("always_read_value_from_ldap").(bool))
+	config.SetBool("is.mandatory.in.ldap", d.Get("is_mandatory_in_ldap").(bool))
+	config.Set("attribute.default.value", d.Get("attribute_default_value").(string))
+	config.SetBool("is.binary.attribute", d.Get("is_binary_attribute").(bool))
+
+	return config, nil
+}
+
+func setLdapUserAttributeMapperConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	d.Set("user_model_attribute", config.Get("user.model.attribute"))
+	d.Set("ldap_attribute", config.Get("ldap.attribute"))
+	d.Set("read_only", config.GetBool("read.only"))
+	d.Set("always_read_value_from_ldap", config.GetBool("always.read.value.from.ldap"))
+	d.Set("is_mandatory_in_ldap", config.GetBool("is.mandatory.in.ldap"))
+	d.Set("attribute_default_value", config.Get("attribute.default.value"))
+	d.Set("is_binary_attribute", config.GetBool("is.binary.attribute"))
+}
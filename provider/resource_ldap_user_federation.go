@@ -0,0 +1,370 @@
+// This file provides a Terraform resource for LDAP (and Active Directory) user federation providers.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// The attributes the admin console fills in when picking a vendor
+type ldapVendorPreset struct {
+	usernameAttribute string
+	rdnAttribute      string
+	uuidAttribute     string
+	userObjectClasses []string
+}
+
+var ldapVendorPresets = map[string]ldapVendorPreset{
+	"ad":         {"cn", "cn", "objectGUID", []string{"person", "organizationalPerson", "user"}},
+	"rhds":       {"uid", "uid", "nsuniqueid", []string{"inetOrgPerson", "organizationalPerson"}},
+	"tivoli":     {"uid", "uid", "uniqueidentifier", []string{"inetOrgPerson", "organizationalPerson"}},
+	"edirectory": {"uid", "uid", "guid", []string{"inetOrgPerson", "organizationalPerson"}},
+	"other":      {"uid", "uid", "entryUUID", []string{"inetOrgPerson", "organizationalPerson"}},
+}
+
+var ldapSearchScopes = map[string]string{
+	"ONE_LEVEL": "1",
+	"SUBTREE":   "2",
+}
+
+func resourceLdapUserFederation() *schema.Resource {
+	r := resourceTypedComponent(&componentType{
+		providerId:   "ldap",
+		providerType: userStorageProviderType,
+		parentId:     realmParentId,
+		getConfig:    getLdapUserFederationConfig,
+		setConfig:    setLdapUserFederationConfig,
+		schema: userFederationSchema(map[string]*schema.Schema{
+			// Keycloak creates a set of default mappers along with the provider. These are deleted right after creating
+			// the provider when set, otherwise mappers with the same name adopt them. Changes after creating the provider
+			// are ignored, as there is nothing left to delete (or to bring back).
+			"delete_default_mappers": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"import_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"edit_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "READ_ONLY",
+				ValidateFunc: validateOneOf("READ_ONLY", "WRITABLE", "UNSYNCED"),
+			},
+			"sync_registrations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// The vendor picks the defaults of the LDAP attributes and object classes below
+			"vendor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "other",
+				ValidateFunc: validateOneOf("ad", "rhds", "tivoli", "edirectory", "other"),
+			},
+			"username_ldap_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"rdn_ldap_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"uuid_ldap_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"user_object_classes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"users_dn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Without a bind DN, Keycloak binds anonymously
+			"bind_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bind_credential": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"custom_user_search_filter": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateLdapFilter,
+			},
+			"search_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ONE_LEVEL",
+				ValidateFunc: validateOneOf("ONE_LEVEL", "SUBTREE"),
+			},
+			"validate_password_policy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"trust_email": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"use_truststore_spi": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ldapsOnly",
+				ValidateFunc: validateOneOf("always", "ldapsOnly", "never"),
+			},
+			// Timeouts are in milliseconds, 0 uses the JNDI defaults
+			"connection_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			"read_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			"pagination": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"batch_size_for_sync": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validateIntAtLeast(1),
+			},
+			// Sync periods are in seconds, -1 disables the periodic sync
+			"full_sync_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validateIntAtLeast(-1),
+			},
+			"changed_sync_period": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      -1,
+				ValidateFunc: validateIntAtLeast(-1),
+			},
+			// Present means Kerberos authentication (SPNEGO) is allowed
+			"kerberos": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kerberos_realm": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"server_principal": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key_tab": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"use_kerberos_for_password_authentication": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+		}),
+	})
+
+	r.CustomizeDiff = applyLdapVendorPreset
+
+	create := r.Create
+	r.Create = func(d *schema.ResourceData, m interface{}) error {
+		err := create(d, m)
+		if err != nil || !d.Get("delete_default_mappers").(bool) {
+			return err
+		}
+
+		return deleteDefaultLdapMappers(m.(*keycloak.KeycloakClient), realm(d), d.Id())
+	}
+
+	return r
+}
+
+// When the vendor changes, attributes still holding the old vendor's preset are switched to the new vendor's preset,
+// while customized attributes are kept.
+func applyLdapVendorPreset(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("vendor") {
+		return nil
+	}
+
+	oldVendor, newVendor := d.GetChange("vendor")
+	oldPreset, newPreset := ldapVendorPresets[oldVendor.(string)], ldapVendorPresets[newVendor.(string)]
+
+	attributes := map[string][2]string{
+		"username_ldap_attribute": {oldPreset.usernameAttribute, newPreset.usernameAttribute},
+		"rdn_ldap_attribute":      {oldPreset.rdnAttribute, newPreset.rdnAttribute},
+		"uuid_ldap_attribute":     {oldPreset.uuidAttribute, newPreset.uuidAttribute},
+	}
+	for field, presets := range attributes {
+		if d.Get(field).(string) == presets[0] {
+			if err := d.SetNew(field, presets[1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	objectClasses := []string{}
+	for _, objectClass := range d.Get("user_object_classes").([]interface{}) {
+		objectClasses = append(objectClasses, objectClass.(string))
+	}
+	if strings.Join(objectClasses, ", ") == strings.Join(oldPreset.userObjectClasses, ", ") {
+		return d.SetNew("user_object_classes", newPreset.userObjectClasses)
+	}
+
+	return nil
+}
+
+func validateLdapFilter(v interface{}, k string) (w []string, err []error) {
+	filter := v.(string)
+	if filter != "" && (!strings.HasPrefix(filter, "(") || !strings.HasSuffix(filter, ")")) {
+		err = []error{
+			fmt.Errorf("Invalid value for %s. LDAP filters must be enclosed in parentheses", k),
+		}
+	}
+	return
+}
+
+func getLdapUserFederationConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := getUserFederationConfig(d)
+	preset := ldapVendorPresets[d.Get("vendor").(string)]
+
+	config.SetBool("importEnabled", d.Get("import_enabled").(bool))
+	config.Set("editMode", d.Get("edit_mode").(string))
+	config.SetBool("syncRegistrations", d.Get("sync_registrations").(bool))
+	config.Set("vendor", d.Get("vendor").(string))
+
+	config.Set("usernameLDAPAttribute", preset.usernameAttribute)
+	if v, present := d.GetOk("username_ldap_attribute"); present {
+		config.Set("usernameLDAPAttribute", v.(string))
+	}
+	config.Set("rdnLDAPAttribute", preset.rdnAttribute)
+	if v, present := d.GetOk("rdn_ldap_attribute"); present {
+		config.Set("rdnLDAPAttribute", v.(string))
+	}
+	config.Set("uuidLDAPAttribute", preset.uuidAttribute)
+	if v, present := d.GetOk("uuid_ldap_attribute"); present {
+		config.Set("uuidLDAPAttribute", v.(string))
+	}
+	// Keycloak expects the object classes as a single comma separated value
+	config.Set("userObjectClasses", strings.Join(preset.userObjectClasses, ", "))
+	if objectClasses := getStringSlice(d, "user_object_classes"); len(objectClasses) > 0 {
+		config.Set("userObjectClasses", strings.Join(objectClasses, ", "))
+	}
+
+	config.Set("connectionUrl", d.Get("connection_url").(string))
+	config.Set("usersDn", d.Get("users_dn").(string))
+	config.Set("authType", "none")
+	if bindDn := d.Get("bind_dn").(string); bindDn != "" {
+		config.Set("authType", "simple")
+		config.Set("bindDn", bindDn)
+		config.Set("bindCredential", d.Get("bind_credential").(string))
+	}
+	config.Set("customUserSearchFilter", d.Get("custom_user_search_filter").(string))
+	config.Set("searchScope", ldapSearchScopes[d.Get("search_scope").(string)])
+	config.SetBool("validatePasswordPolicy", d.Get("validate_password_policy").(bool))
+	config.SetBool("trustEmail", d.Get("trust_email").(bool))
+	config.Set("useTruststoreSpi", d.Get("use_truststore_spi").(string))
+	config.Set("connectionTimeout", intAttribute(d.Get("connection_timeout").(int)))
+	config.Set("readTimeout", intAttribute(d.Get("read_timeout").(int)))
+	config.SetBool("pagination", d.Get("pagination").(bool))
+	config.SetInt("batchSizeForSync", d.Get("batch_size_for_sync").(int))
+	config.SetInt("fullSyncPeriod", d.Get("full_sync_period").(int))
+	config.SetInt("changedSyncPeriod", d.Get("changed_sync_period").(int))
+
+	config.SetBool("allowKerberosAuthentication", false)
+	if v, present := d.GetOk("kerberos"); present {
+		kerberos := v.([]interface{})[0].(map[string]interface{})
+		config.SetBool("allowKerberosAuthentication", true)
+		config.Set("kerberosRealm", kerberos["kerberos_realm"].(string))
+		config.Set("serverPrincipal", kerberos["server_principal"].(string))
+		config.Set("keyTab", kerberos["key_tab"].(string))
+		config.SetBool("useKerberosForPasswordAuthentication", kerberos["use_kerberos_for_password_authentication"].(bool))
+	}
+
+	return config, nil
+}
+
+func setLdapUserFederationConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setUserFederationConfig(config, d)
+	d.Set("import_enabled", config.GetBool("importEnabled"))
+	d.Set("edit_mode", config.Get("editMode"))
+	d.Set("sync_registrations", config.GetBool("syncRegistrations"))
+	d.Set("vendor", config.Get("vendor"))
+	d.Set("username_ldap_attribute", config.Get("usernameLDAPAttribute"))
+	d.Set("rdn_ldap_attribute", config.Get("rdnLDAPAttribute"))
+	d.Set("uuid_ldap_attribute", config.Get("uuidLDAPAttribute"))
+
+	d.Set("user_object_classes", splitCommaList(config.Get("userObjectClasses")))
+
+	d.Set("connection_url", config.Get("connectionUrl"))
+	d.Set("users_dn", config.Get("usersDn"))
+	d.Set("bind_dn", config.Get("bindDn"))
+	setConfigSecret(d, "bind_credential", config.Get("bindCredential"))
+	d.Set("custom_user_search_filter", config.Get("customUserSearchFilter"))
+	for name, scope := range ldapSearchScopes {
+		if scope == config.Get("searchScope") {
+			d.Set("search_scope", name)
+		}
+	}
+	d.Set("validate_password_policy", config.GetBool("validatePasswordPolicy"))
+	d.Set("trust_email", config.GetBool("trustEmail"))
+	d.Set("use_truststore_spi", config.Get("useTruststoreSpi"))
+	d.Set("connection_timeout", config.GetInt("connectionTimeout"))
+	d.Set("read_timeout", config.GetInt("readTimeout"))
+	d.Set("pagination", config.GetBool("pagination"))
+	d.Set("batch_size_for_sync", config.GetInt("batchSizeForSync"))
+	d.Set("full_sync_period", config.GetInt("fullSyncPeriod"))
+	d.Set("changed_sync_period", config.GetInt("changedSyncPeriod"))
+
+	if config.GetBool("allowKerberosAuthentication") {
+		d.Set("kerberos", []interface{}{
+			map[string]interface{}{
+				"kerberos_realm":   config.Get("kerberosRealm"),
+				"server_principal": config.Get("serverPrincipal"),
+				"key_tab":          config.Get("keyTab"),
+				"use_kerberos_for_password_authentication": config.GetBool("useKerberosForPasswordAuthentication"),
+			},
+		})
+	} else {
+		d.Set("kerberos", nil)
+	}
+}
@@ -0,0 +1,107 @@
+// This file provides a Terraform resource for generic OpenID Connect identity providers.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceOidcIdentityProvider() *schema.Resource {
+	return resourceIdentityProvider(&identityProviderType{
+		providerId: "oidc",
+		getConfig:  getOidcIdentityProviderConfig,
+		setConfig:  setOidcIdentityProviderConfig,
+		schema: map[string]*schema.Schema{
+			"authorization_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"token_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_info_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"jwks_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logout_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"issuer": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"client_secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"client_auth_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "client_secret_post",
+				ValidateFunc: validateOneOf("client_secret_post", "client_secret_basic", "client_secret_jwt", "private_key_jwt"),
+			},
+			"default_scopes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "openid",
+			},
+			"validate_signature": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"backchannel_supported": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	})
+}
+
+func getOidcIdentityProviderConfig(d *schema.ResourceData, _ *keycloak.KeycloakClient) (map[string]string, error) {
+	return map[string]string{
+		"authorizationUrl":     d.Get("authorization_url").(string),
+		"tokenUrl":             d.Get("token_url").(string),
+		"userInfoUrl":          d.Get("user_info_url").(string),
+		"jwksUrl":              d.Get("jwks_url").(string),
+		"useJwksUrl":           fmt.Sprintf("%t", d.Get("jwks_url").(string) != ""),
+		"logoutUrl":            d.Get("logout_url").(string),
+		"issuer":               d.Get("issuer").(string),
+		"clientId":             d.Get("client_id").(string),
+		"clientSecret":         d.Get("client_secret").(string),
+		"clientAuthMethod":     d.Get("client_auth_method").(string),
+		"defaultScope":         d.Get("default_scopes").(string),
+		"validateSignature":    fmt.Sprintf("%t", d.Get("validate_signature").(bool)),
+		"backchannelSupported": fmt.Sprintf("%t", d.Get("backchannel_supported").(bool)),
+	}, nil
+}
+
+func setOidcIdentityProviderConfig(config map[string]string, d *schema.ResourceData) {
+	d.Set("authorization_url", config["authorizationUrl"])
+	d.Set("token_url", config["tokenUrl"])
+	d.Set("user_info_url", config["userInfoUrl"])
+	d.Set("jwks_url", config["jwksUrl"])
+	d.Set("logout_url", config["logoutUrl"])
+	d.Set("issuer", config["issuer"])
+	d.Set("client_id", config["clientId"])
+	setConfigSecret(d, "client_secret", config["clientSecret"])
+	d.Set("client_auth_method", config["clientAuthMethod"])
+	d.Set("default_scopes", config["defaultScope"])
+	d.Set("validate_signature", config["validateSignature"] == "true")
+	d.Set("backchannel_supported", config["backchannelSupported"] == "true")
+}
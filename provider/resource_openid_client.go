@@ -0,0 +1,391 @@
+// This file provides a Terraform resource for Keycloak clients using the openid-connect protocol.
+// Protocol-specific settings which Keycloak stores as client attributes are exposed as typed fields.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+const (
+	openidAttributePkceMethod           = "pkce.code.challenge.method"
+	openidAttributeFrontchannelUrl      = "frontchannel.logout.url"
+	openidAttributeBackchannelUrl       = "backchannel.logout.url"
+	openidAttributeBackchannelSession   = "backchannel.logout.session.required"
+	openidAttributeAccessTokenLifespan  = "access.token.lifespan"
+	openidAttributeSessionIdleTimeout   = "client.session.idle.timeout"
+	openidAttributeSessionMaxLifespan   = "client.session.max.lifespan"
+	openidAttributeUseRefreshTokens     = "use.refresh.tokens"
+	openidAttributeExcludeSessionState  = "exclude.session.state.from.auth.response"
+	openidAttributeUseJwksUrl           = "use.jwks.url"
+	openidAttributeJwksUrl              = "jwks.url"
+	openidAccessTypeConfidential        = "CONFIDENTIAL"
+	openidAccessTypePublic              = "PUBLIC"
+	openidAccessTypeBearerOnly          = "BEARER-ONLY"
+	openidClientProtocol                = "openid-connect"
+	openidClientAuthenticatorTypeSecret = "client-secret"
+)
+
+func resourceOpenidClient() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceOpenidClientRead),
+		Create: schema.CreateFunc(resourceOpenidClientCreate),
+		Update: schema.UpdateFunc(resourceOpenidClientUpdate),
+		Delete: schema.DeleteFunc(resourceClientDelete),
+
+		CustomizeDiff: validateOpenidClientAccessType,
+
+		// Keycloak clients are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importOpenidClientHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "master",
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"access_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validateOneOf(
+					openidAccessTypeConfidential,
+					openidAccessTypePublic,
+					openidAccessTypeBearerOnly,
+				),
+			},
+			"client_authenticator_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      openidClientAuthenticatorTypeSecret,
+				ValidateFunc: validateOneOf(openidClientAuthenticatorTypeSecret, "client-jwt", "client-secret-jwt", "client-x509"),
+			},
+			"redirect_uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"web_origins": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"root_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"admin_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"base_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"standard_flow_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"implicit_flow_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"direct_access_grants_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"service_accounts_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"full_scope_allowed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// Client specific flows (by flow ID), overriding the realm's browser and direct grant flow bindings
+			"authentication_flow_binding_overrides": authenticationFlowBindingOverridesSchema(),
+			// Used with the `client-jwt` authenticator, instead of uploading a certificate with keycloak_client_certificate
+			"jwks_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"consent_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"pkce_code_challenge_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "",
+				ValidateFunc: validateOneOf("", "plain", "S256"),
+			},
+			"frontchannel_logout_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"frontchannel_logout_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"backchannel_logout_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"backchannel_logout_session_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// Token lifespans are in seconds, leaving them unset (or 0) uses the realm settings.
+			"access_token_lifespan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			"client_session_idle_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			"client_session_max_lifespan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			"use_refresh_tokens": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"exclude_session_state_from_auth_response": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			// Computed fields (i.e. things looked up in Keycloak after client creation)
+			"client_secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"service_account_user_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Keycloak silently ignores some settings depending on the access type, so reject those combinations during plan.
+func validateOpenidClientAccessType(d *schema.ResourceDiff, _ interface{}) error {
+	accessType := d.Get("access_type").(string)
+
+	if accessType != openidAccessTypeConfidential && d.Get("service_accounts_enabled").(bool) {
+		return fmt.Errorf("service_accounts_enabled can only be set when access_type is %s", openidAccessTypeConfidential)
+	}
+
+	if accessType == openidAccessTypeBearerOnly {
+		for _, flow := range []string{"standard_flow_enabled", "implicit_flow_enabled", "direct_access_grants_enabled"} {
+			if d.Get(flow).(bool) {
+				return fmt.Errorf("%s can not be set when access_type is %s", flow, openidAccessTypeBearerOnly)
+			}
+		}
+	}
+
+	return nil
+}
+
+func importOpenidClientHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	resourceOpenidClientRead(d, m)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceOpenidClientRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	client, err := c.GetClient(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	openidClientToResourceData(client, d)
+
+	/** Get computed fields **/
+	// Public clients don't have a secret
+	if !client.PublicClient {
+		secret, err := c.GetClientSecret(d.Id(), realm(d))
+		if err != nil {
+			return err
+		}
+		d.Set("client_secret", secret.Value)
+	}
+
+	// Look up service account user ID (if enabled)
+	if client.ServiceAccountsEnabled {
+		user, err := c.GetClientServiceAccountUser(d.Id(), realm(d))
+		if err != nil {
+			return err
+		}
+
+		d.Set("service_account_user_id", user.Id)
+	}
+
+	return nil
+}
+
+func resourceOpenidClientCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	client := resourceDataToOpenidClient(d)
+	created, err := apiClient.CreateClient(&client, realm(d))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceOpenidClientRead(d, m)
+}
+
+func resourceOpenidClientUpdate(d *schema.ResourceData, m interface{}) error {
+	client := resourceDataToOpenidClient(d)
+	apiClient := m.(*keycloak.KeycloakClient)
+	err := apiClient.UpdateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceOpenidClientRead(d, m)
+}
+
+func resourceDataToOpenidClient(d *schema.ResourceData) keycloak.Client {
+	accessType := d.Get("access_type").(string)
+	consentRequired := d.Get("consent_required").(bool)
+	frontchannelLogout := d.Get("frontchannel_logout_enabled").(bool)
+
+	attributes := map[string]interface{}{
+		openidAttributePkceMethod:          d.Get("pkce_code_challenge_method").(string),
+		openidAttributeFrontchannelUrl:     d.Get("frontchannel_logout_url").(string),
+		openidAttributeBackchannelUrl:      d.Get("backchannel_logout_url").(string),
+		openidAttributeBackchannelSession:  fmt.Sprintf("%t", d.Get("backchannel_logout_session_required").(bool)),
+		openidAttributeAccessTokenLifespan: intAttribute(d.Get("access_token_lifespan").(int)),
+		openidAttributeSessionIdleTimeout:  intAttribute(d.Get("client_session_idle_timeout").(int)),
+		openidAttributeSessionMaxLifespan:  intAttribute(d.Get("client_session_max_lifespan").(int)),
+		openidAttributeUseRefreshTokens:    fmt.Sprintf("%t", d.Get("use_refresh_tokens").(bool)),
+		openidAttributeExcludeSessionState: fmt.Sprintf("%t", d.Get("exclude_session_state_from_auth_response").(bool)),
+		openidAttributeJwksUrl:             d.Get("jwks_url").(string),
+		openidAttributeUseJwksUrl:          fmt.Sprintf("%t", d.Get("jwks_url").(string) != ""),
+	}
+
+	c := keycloak.Client{
+		ClientId:                           d.Get("client_id").(string),
+		Enabled:                            d.Get("enabled").(bool),
+		ClientAuthenticatorType:            d.Get("client_authenticator_type").(string),
+		RedirectUris:                       getStringSlice(d, "redirect_uris"),
+		Protocol:                           openidClientProtocol,
+		PublicClient:                       accessType == openidAccessTypePublic,
+		BearerOnly:                         accessType == openidAccessTypeBearerOnly,
+		ServiceAccountsEnabled:             d.Get("service_accounts_enabled").(bool),
+		DirectAccessGrantsEnabled:          d.Get("direct_access_grants_enabled").(bool),
+		ImplicitFlowEnabled:                d.Get("implicit_flow_enabled").(bool),
+		StandardFlowEnabled:                d.Get("standard_flow_enabled").(bool),
+		WebOrigins:                         getStringSlice(d, "web_origins"),
+		RootUrl:                            d.Get("root_url").(string),
+		AdminUrl:                           d.Get("admin_url").(string),
+		BaseUrl:                            d.Get("base_url").(string),
+		AuthenticationFlowBindingOverrides: getAuthenticationFlowBindingOverrides(d),
+		FullScopeAllowed:                   d.Get("full_scope_allowed").(bool),
+		ConsentRequired:                    &consentRequired,
+		FrontchannelLogout:                 &frontchannelLogout,
+		Attributes:                         attributes,
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return c
+}
+
+func openidClientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
+	accessType := openidAccessTypeConfidential
+	if c.PublicClient {
+		accessType = openidAccessTypePublic
+	} else if c.BearerOnly {
+		accessType = openidAccessTypeBearerOnly
+	}
+
+	d.Set("client_id", c.ClientId)
+	d.Set("enabled", c.Enabled)
+	d.Set("access_type", accessType)
+	d.Set("client_authenticator_type", c.ClientAuthenticatorType)
+	d.Set("redirect_uris", c.RedirectUris)
+	d.Set("web_origins", c.WebOrigins)
+	d.Set("root_url", c.RootUrl)
+	d.Set("admin_url", c.AdminUrl)
+	d.Set("base_url", c.BaseUrl)
+	d.Set("standard_flow_enabled", c.StandardFlowEnabled)
+	d.Set("implicit_flow_enabled", c.ImplicitFlowEnabled)
+	d.Set("direct_access_grants_enabled", c.DirectAccessGrantsEnabled)
+	d.Set("service_accounts_enabled", c.ServiceAccountsEnabled)
+	d.Set("full_scope_allowed", c.FullScopeAllowed)
+	setAuthenticationFlowBindingOverrides(c.AuthenticationFlowBindingOverrides, d)
+	setOptionalBool(d, "consent_required", c.ConsentRequired)
+	setOptionalBool(d, "frontchannel_logout_enabled", c.FrontchannelLogout)
+
+	d.Set("pkce_code_challenge_method", getAttributeString(c.Attributes, openidAttributePkceMethod))
+	d.Set("frontchannel_logout_url", getAttributeString(c.Attributes, openidAttributeFrontchannelUrl))
+	d.Set("backchannel_logout_url", getAttributeString(c.Attributes, openidAttributeBackchannelUrl))
+	d.Set("backchannel_logout_session_required", getAttributeBoolDefault(c.Attributes, openidAttributeBackchannelSession, true))
+	d.Set("access_token_lifespan", getAttributeInt(c.Attributes, openidAttributeAccessTokenLifespan))
+	d.Set("client_session_idle_timeout", getAttributeInt(c.Attributes, openidAttributeSessionIdleTimeout))
+	d.Set("client_session_max_lifespan", getAttributeInt(c.Attributes, openidAttributeSessionMaxLifespan))
+	d.Set("exclude_session_state_from_auth_response", getAttributeBool(c.Attributes, openidAttributeExcludeSessionState))
+
+	if getAttributeBool(c.Attributes, openidAttributeUseJwksUrl) {
+		d.Set("jwks_url", getAttributeString(c.Attributes, openidAttributeJwksUrl))
+	} else {
+		d.Set("jwks_url", "")
+	}
+
+	d.Set("use_refresh_tokens", getAttributeBoolDefault(c.Attributes, openidAttributeUseRefreshTokens, true))
+}
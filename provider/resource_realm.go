@@ -15,6 +15,8 @@ func resourceRealm() *schema.Resource {
 		Update: schema.UpdateFunc(resourceRealmUpdate),
 		Delete: schema.DeleteFunc(resourceRealmDelete),
 
+		CustomizeDiff: validateRealmPasswordPolicy,
+
 		// Realms are importable by ID
 		Importer: &schema.ResourceImporter{},
 
@@ -53,12 +55,24 @@ func resourceRealm() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
-			"smtp_server": {
-				Type:             schema.TypeMap,
-				Optional:         true,
-				DiffSuppressFunc: ignoreSmtpPasswordChange,
-			},
+			"smtp_server": smtpServerSchema(),
+
+			"password_policy":               passwordPolicySchema(),
+			"otp_policy":                    otpPolicySchema(),
+			"web_authn_policy":              webAuthnPolicySchema(),
+			"web_authn_passwordless_policy": webAuthnPolicySchema(),
+			"brute_force_detection":         bruteForceDetectionSchema(),
 
+			// Replaced by the brute_force_detection block
+			"brute_force_protected":            deprecatedBruteForceSchema(schema.TypeBool, "add the brute_force_detection block instead"),
+			"failure_factor":                   deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.max_login_failures instead"),
+			"max_failure_wait_seconds":         deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.max_failure_wait_seconds instead"),
+			"minimum_quick_login_wait_seconds": deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.minimum_quick_login_wait_seconds instead"),
+			"wait_increment_seconds":           deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.wait_increment_seconds instead"),
+			"quick_login_check_milli_seconds":  deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.quick_login_check_milli_seconds instead"),
+			"max_delta_time_seconds":           deprecatedBruteForceSchema(schema.TypeInt, "use brute_force_detection.failure_reset_time_seconds instead"),
+
+			"security_defenses": securityDefensesSchema(),
 			"internationalization_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -87,10 +101,6 @@ func resourceRealm() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
-			"brute_force_protected": {
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
 			"access_token_lifespan": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -131,69 +141,57 @@ func resourceRealm() *schema.Resource {
 				Optional: true,
 				Default:  1800,
 			},
-			"max_failure_wait_seconds": {
-				Type:     schema.TypeInt,
+			"account_theme": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  900,
 			},
-			"minimum_quick_login_wait_seconds": {
-				Type:     schema.TypeInt,
+			"admin_theme": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  60,
 			},
-			"wait_increment_seconds": {
-				Type:     schema.TypeInt,
+			"email_theme": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  60,
 			},
-			"quick_login_check_milli_seconds": {
-				Type:     schema.TypeInt,
+			"login_theme": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  1000,
 			},
-			"max_delta_time_seconds": {
-				Type:     schema.TypeInt,
+			// Authentication flow bindings (by flow alias), Keycloak binds its built-in flows by default
+			"browser_flow": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  43200,
+				Computed: true,
 			},
-			"failure_factor": {
-				Type:     schema.TypeInt,
+			"registration_flow": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Default:  30,
+				Computed: true,
 			},
-			"account_theme": {
+			"direct_grant_flow": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
-			"admin_theme": {
+			"reset_credentials_flow": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
-			"email_theme": {
+			"client_authentication_flow": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
-			"login_theme": {
+			"docker_authentication_flow": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 		},
 	}
 }
 
-// Keycloak returns some asterisks instead of the plaintext password when querying for the realm configuration.
-// Due to this Terraform will assume a change has happened and attempt to reset the password.
-// This function will ignore the planned change in such a case, but it will also currently make it impossible to
-// change the password (because it's not persisted in the state).
-func ignoreSmtpPasswordChange(k, old, new string, d *schema.ResourceData) bool {
-	if k == "smtp_server.password" && old == "**********" {
-		// It would be nice to print a warning here, but it's unclear how/if providers can output things.
-		return true
-	}
-
-	return false
-}
-
 func validateSslRequired(v interface{}, _ string) (w []string, err []error) {
 	switch v.(string) {
 	case
@@ -224,6 +222,11 @@ func resourceRealmCreate(d *schema.ResourceData, m interface{}) error {
 	c := m.(*keycloak.KeycloakClient)
 	r := resourceDataToRealm(d)
 
+	// The flows only exist once the realm does, so they are bound afterwards
+	bindings := *r
+	r.BrowserFlow, r.RegistrationFlow, r.DirectGrantFlow = "", "", ""
+	r.ResetCredentialsFlow, r.ClientAuthenticationFlow, r.DockerAuthenticationFlow = "", "", ""
+
 	created, err := c.CreateRealm(r)
 	if err != nil {
 		return err
@@ -231,12 +234,29 @@ func resourceRealmCreate(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(created.Id)
 
+	if bindings.BrowserFlow != "" || bindings.RegistrationFlow != "" || bindings.DirectGrantFlow != "" ||
+		bindings.ResetCredentialsFlow != "" || bindings.ClientAuthenticationFlow != "" || bindings.DockerAuthenticationFlow != "" {
+		err = updateRealm(c, &bindings)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceRealmRead(d, m)
 }
 
 func resourceRealmUpdate(d *schema.ResourceData, m interface{}) error {
 	c := m.(*keycloak.KeycloakClient)
-	r := resourceDataToRealm(d)
+	return updateRealm(c, resourceDataToRealm(d))
+}
+
+func updateRealm(c *keycloak.KeycloakClient, r *keycloak.Realm) error {
+	err := validateAuthenticationFlowAliases(c, r.Realm, r.BrowserFlow, r.RegistrationFlow, r.DirectGrantFlow,
+		r.ResetCredentialsFlow, r.ClientAuthenticationFlow, r.DockerAuthenticationFlow)
+	if err != nil {
+		return err
+	}
+
 	return c.UpdateRealm(r)
 }
 
@@ -256,12 +276,21 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		DisplayName:      d.Get("display_name").(string),
 		SupportedLocales: getStringSlice(d, "supported_locales"),
 		DefaultRoles:     getStringSlice(d, "default_roles"),
+		SmtpServer:       getRealmSmtpServer(d),
+		PasswordPolicy:   getRealmPasswordPolicy(d),
 
 		AccountTheme: d.Get("account_theme").(string),
 		AdminTheme:   d.Get("admin_theme").(string),
 		EmailTheme:   d.Get("email_theme").(string),
 		LoginTheme:   d.Get("login_theme").(string),
 
+		BrowserFlow:              d.Get("browser_flow").(string),
+		RegistrationFlow:         d.Get("registration_flow").(string),
+		DirectGrantFlow:          d.Get("direct_grant_flow").(string),
+		ResetCredentialsFlow:     d.Get("reset_credentials_flow").(string),
+		ClientAuthenticationFlow: d.Get("client_authentication_flow").(string),
+		DockerAuthenticationFlow: d.Get("docker_authentication_flow").(string),
+
 		InternationalizationEnabled: getOptionalBool(d, "internationalization_enabled"),
 		RegistrationAllowed:         getOptionalBool(d, "registration_allowed"),
 		RegistrationEmailAsUsername: getOptionalBool(d, "registration_email_as_username"),
@@ -269,7 +298,6 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		VerifyEmail:                 getOptionalBool(d, "verify_email"),
 		ResetPasswordAllowed:        getOptionalBool(d, "reset_password_allowed"),
 		EditUsernameAllowed:         getOptionalBool(d, "edit_username_allowed"),
-		BruteForceProtected:         getOptionalBool(d, "brute_force_protected"),
 
 		AccessTokenLifespan:                getOptionalInt(d, "access_token_lifespan"),
 		AccessTokenLifespanForImplicitFlow: getOptionalInt(d, "access_token_lifespan_for_implicit_flow"),
@@ -279,12 +307,6 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		AccessCodeLifespan:                 getOptionalInt(d, "access_code_lifespan"),
 		AccessCodeLifespanUserAction:       getOptionalInt(d, "access_code_lifespan_user_action"),
 		AccessCodeLifespanLogin:            getOptionalInt(d, "access_code_lifespan_login"),
-		MaxFailureWaitSeconds:              getOptionalInt(d, "max_failure_wait_seconds"),
-		MinimumQuickLoginWaitSeconds:       getOptionalInt(d, "minimum_quick_login_wait_seconds"),
-		WaitIncrementSeconds:               getOptionalInt(d, "wait_increment_seconds"),
-		QuickLoginCheckMilliSeconds:        getOptionalInt(d, "quick_login_check_milli_seconds"),
-		MaxDeltaTimeSeconds:                getOptionalInt(d, "max_delta_time_seconds"),
-		FailureFactor:                      getOptionalInt(d, "failure_factor"),
 	}
 
 	if !d.IsNewResource() {
@@ -293,10 +315,11 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		r.Id = r.Realm
 	}
 
-	if smtpMap, present := d.GetOk("smtp_server"); present {
-		smtp := keycloak.SmtpServer(smtpMap.(map[string]interface{}))
-		r.SmtpServer = &smtp
-	}
+	getRealmOtpPolicy(d, &r)
+	getRealmWebAuthnPolicy(d, &r, "web_authn_policy", false)
+	getRealmWebAuthnPolicy(d, &r, "web_authn_passwordless_policy", true)
+	getRealmBruteForceDetection(d, &r)
+	getRealmSecurityDefenses(d, &r)
 
 	return &r
 }
@@ -310,15 +333,25 @@ func realmToResourceData(r *keycloak.Realm, d *schema.ResourceData) {
 	d.Set("display_name", r.DisplayName)
 	d.Set("supported_locales", r.SupportedLocales)
 	d.Set("default_roles", r.DefaultRoles)
+	setRealmSmtpServer(r.SmtpServer, d)
+	setRealmPasswordPolicy(r.PasswordPolicy, d)
+	setRealmOtpPolicy(r, d)
+	setRealmWebAuthnPolicy(r, d, "web_authn_policy", false)
+	setRealmWebAuthnPolicy(r, d, "web_authn_passwordless_policy", true)
+	setRealmBruteForceDetection(r, d)
+	setRealmSecurityDefenses(r, d)
 
 	d.Set("account_theme", r.AccountTheme)
 	d.Set("admin_theme", r.AdminTheme)
 	d.Set("email_theme", r.EmailTheme)
 	d.Set("login_theme", r.LoginTheme)
 
-	if r.SmtpServer != nil {
-		d.Set("smtp_server", *r.SmtpServer)
-	}
+	d.Set("browser_flow", r.BrowserFlow)
+	d.Set("registration_flow", r.RegistrationFlow)
+	d.Set("direct_grant_flow", r.DirectGrantFlow)
+	d.Set("reset_credentials_flow", r.ResetCredentialsFlow)
+	d.Set("client_authentication_flow", r.ClientAuthenticationFlow)
+	d.Set("docker_authentication_flow", r.DockerAuthenticationFlow)
 
 	setOptionalBool(d, "internationalization_enabled", r.InternationalizationEnabled)
 	setOptionalBool(d, "registration_allowed", r.RegistrationAllowed)
@@ -327,7 +360,6 @@ func realmToResourceData(r *keycloak.Realm, d *schema.ResourceData) {
 	setOptionalBool(d, "verify_email", r.VerifyEmail)
 	setOptionalBool(d, "reset_password_allowed", r.ResetPasswordAllowed)
 	setOptionalBool(d, "edit_username_allowed", r.EditUsernameAllowed)
-	setOptionalBool(d, "brute_force_protected", r.BruteForceProtected)
 
 	setOptionalInt(d, "access_token_lifespan", r.AccessTokenLifespan)
 	setOptionalInt(d, "access_token_lifespan_for_implicit_flow", r.AccessTokenLifespanForImplicitFlow)
@@ -337,10 +369,4 @@ func realmToResourceData(r *keycloak.Realm, d *schema.ResourceData) {
 	setOptionalInt(d, "access_code_lifespan", r.AccessCodeLifespan)
 	setOptionalInt(d, "access_code_lifespan_user_action", r.AccessCodeLifespanUserAction)
 	setOptionalInt(d, "access_code_lifespan_login", r.AccessCodeLifespanLogin)
-	setOptionalInt(d, "max_failure_wait_seconds", r.MaxFailureWaitSeconds)
-	setOptionalInt(d, "minimum_quick_login_wait_seconds", r.MinimumQuickLoginWaitSeconds)
-	setOptionalInt(d, "wait_increment_seconds", r.WaitIncrementSeconds)
-	setOptionalInt(d, "quick_login_check_milli_seconds", r.QuickLoginCheckMilliSeconds)
-	setOptionalInt(d, "max_delta_time_seconds", r.MaxDeltaTimeSeconds)
-	setOptionalInt(d, "failure_factor", r.FailureFactor)
 }
@@ -0,0 +1,147 @@
+// This file provides a Terraform resource for the event settings of a realm. The settings always exist, so creating
+// the resource takes them over and deleting it restores Keycloak's defaults.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// The listener Keycloak enables for new realms
+var defaultEventsListeners = []string{"jboss-logging"}
+
+func resourceRealmEvents() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceRealmEventsRead),
+		Create: schema.CreateFunc(resourceRealmEventsCreate),
+		Update: schema.UpdateFunc(resourceRealmEventsUpdate),
+		Delete: schema.DeleteFunc(resourceRealmEventsDelete),
+
+		// The event settings are importable by realm
+		Importer: &schema.ResourceImporter{
+			State: importRealmEventsHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Stores login events
+			"events_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// In seconds, 0 keeps events forever
+			"events_expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateIntAtLeast(0),
+			},
+			// The login event types to store (e.g. `LOGIN_ERROR`), Keycloak stores its default types if empty
+			"enabled_event_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"admin_events_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Includes the representation of the changed object in admin events
+			"admin_events_details_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// The event listener providers receiving the events, the realm's listeners are kept if left out
+			"events_listeners": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func importRealmEventsHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm", d.Id())
+
+	err := resourceRealmEventsRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRealmEventsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	config, err := c.GetRealmEventsConfig(realm(d))
+	if err != nil {
+		// The realm is gone, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("events_enabled", config.EventsEnabled)
+	d.Set("events_expiration", config.EventsExpiration)
+	d.Set("enabled_event_types", config.EnabledEventTypes)
+	d.Set("admin_events_enabled", config.AdminEventsEnabled)
+	d.Set("admin_events_details_enabled", config.AdminEventsDetailsEnabled)
+	d.Set("events_listeners", config.EventsListeners)
+
+	return nil
+}
+
+func resourceRealmEventsCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(realm(d))
+	return resourceRealmEventsUpdate(d, m)
+}
+
+func resourceRealmEventsUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	config := &keycloak.RealmEventsConfig{
+		EventsEnabled:             d.Get("events_enabled").(bool),
+		EventsExpiration:          d.Get("events_expiration").(int),
+		EnabledEventTypes:         getStringSet(d, "enabled_event_types"),
+		AdminEventsEnabled:        d.Get("admin_events_enabled").(bool),
+		AdminEventsDetailsEnabled: d.Get("admin_events_details_enabled").(bool),
+	}
+
+	if _, present := d.GetOk("events_listeners"); present {
+		config.EventsListeners = getStringSet(d, "events_listeners")
+	} else {
+		// Keycloak replaces the listeners with an empty list if none are sent
+		current, err := c.GetRealmEventsConfig(realm(d))
+		if err != nil {
+			return err
+		}
+		config.EventsListeners = current.EventsListeners
+	}
+
+	err := c.UpdateRealmEventsConfig(config, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceRealmEventsRead(d, m)
+}
+
+func resourceRealmEventsDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	return c.UpdateRealmEventsConfig(&keycloak.RealmEventsConfig{
+		EnabledEventTypes: []string{},
+		EventsListeners:   defaultEventsListeners,
+	}, realm(d))
+}
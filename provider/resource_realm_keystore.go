@@ -0,0 +1,144 @@
+// This file provides the parts shared by the realm key provider resources. Key providers are components of the realm
+// which hold (or generate) the keys used to sign and encrypt tokens. The keys themselves are listed separately by
+// Keycloak, which is where the key ID and public parts of a provider's key come from.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+const keyProviderType = "org.keycloak.keys.KeyProvider"
+
+var rsaKeyAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+
+// Returns the key provider resource for the given component type, with the settings shared by all key providers and
+// the computed attributes of its key. Symmetric keys have no public key or certificate.
+func resourceRealmKeystore(t *componentType, asymmetric bool) *schema.Resource {
+	s := map[string]*schema.Schema{
+		// Keys of providers with a higher priority are used first
+		"priority": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		// Inactive keys are only used to verify existing tokens, e.g. while rotating keys
+		"active": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"kid": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	if asymmetric {
+		s["public_key"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		s["certificate"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+	for k, v := range t.schema {
+		s[k] = v
+	}
+	t.providerType = keyProviderType
+	t.parentId = realmParentId
+	t.schema = s
+
+	getConfig, setConfig := t.getConfig, t.setConfig
+	t.getConfig = func(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+		config, err := getConfig(d)
+		if err != nil {
+			return nil, err
+		}
+		config.SetInt("priority", d.Get("priority").(int))
+		config.SetBool("enabled", d.Get("enabled").(bool))
+		config.SetBool("active", d.Get("active").(bool))
+		return config, nil
+	}
+	t.setConfig = func(config keycloak.ComponentConfig, d *schema.ResourceData) {
+		setConfig(config, d)
+		d.Set("priority", config.GetInt("priority"))
+		d.Set("enabled", config.GetBool("enabled"))
+		d.Set("active", config.GetBool("active"))
+	}
+
+	r := resourceTypedComponent(t)
+
+	// The key is only known once the component exists, so its attributes are read after every change
+	r.Read = withRealmKeyAttributes(r.Read, asymmetric)
+	r.Create = withRealmKeyAttributes(r.Create, asymmetric)
+	r.Update = withRealmKeyAttributes(r.Update, asymmetric)
+
+	return r
+}
+
+func withRealmKeyAttributes(f func(*schema.ResourceData, interface{}) error, asymmetric bool) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, m interface{}) error {
+		err := f(d, m)
+		if err != nil || d.Id() == "" {
+			return err
+		}
+
+		return setRealmKeyAttributes(m.(*keycloak.KeycloakClient), d, asymmetric)
+	}
+}
+
+func setRealmKeyAttributes(c *keycloak.KeycloakClient, d *schema.ResourceData, asymmetric bool) error {
+	keys, err := c.ListRealmKeys(realm(d))
+	if err != nil {
+		return err
+	}
+
+	// Disabled providers have no key
+	key := &keycloak.RealmKey{}
+	for _, k := range keys {
+		if k.ProviderId == d.Id() {
+			key = k
+			break
+		}
+	}
+
+	d.Set("kid", key.Kid)
+	if asymmetric {
+		d.Set("public_key", key.PublicKey)
+		// Keycloak lists the bare base64 of the certificate, a configured PEM certificate for the same key is kept
+		if pemBody(d.Get("certificate").(string)) != key.Certificate {
+			d.Set("certificate", key.Certificate)
+		}
+	}
+
+	return nil
+}
+
+// Providers created by older Keycloak versions may have no algorithm, which means the default of the field.
+func setRealmKeystoreAlgorithm(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	if algorithm := config.Get("algorithm"); algorithm != "" {
+		d.Set("algorithm", algorithm)
+	}
+}
+
+// Returns the base64 body of a PEM block, without the BEGIN and END lines and line breaks.
+func pemBody(pem string) string {
+	body := []string{}
+	for _, line := range strings.Split(pem, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "-----") {
+			body = append(body, line)
+		}
+	}
+	return strings.Join(body, "")
+}
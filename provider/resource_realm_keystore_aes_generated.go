@@ -0,0 +1,36 @@
+// This file provides a Terraform resource for AES key providers, which generate the secret used to encrypt tokens
+// internal to Keycloak.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreAesGenerated() *schema.Resource {
+	return resourceRealmKeystore(&componentType{
+		providerId: "aes-generated",
+		getConfig:  getRealmKeystoreAesGeneratedConfig,
+		setConfig:  setRealmKeystoreAesGeneratedConfig,
+		schema: map[string]*schema.Schema{
+			// In bytes, changing it generates a new secret
+			"secret_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      16,
+				ValidateFunc: validateOneOfInt(16, 24, 32),
+			},
+		},
+	}, false)
+}
+
+func getRealmKeystoreAesGeneratedConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+	config.SetInt("secretSize", d.Get("secret_size").(int))
+	return config, nil
+}
+
+func setRealmKeystoreAesGeneratedConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	d.Set("secret_size", config.GetInt("secretSize"))
+}
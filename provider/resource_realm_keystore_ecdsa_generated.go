@@ -0,0 +1,36 @@
+// This file provides a Terraform resource for ECDSA key providers generating their key. The algorithm (ES256, ES384 or
+// ES512) follows from the curve.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreEcdsaGenerated() *schema.Resource {
+	return resourceRealmKeystore(&componentType{
+		providerId: "ecdsa-generated",
+		getConfig:  getRealmKeystoreEcdsaGeneratedConfig,
+		setConfig:  setRealmKeystoreEcdsaGeneratedConfig,
+		schema: map[string]*schema.Schema{
+			// Changing it generates a new key
+			"elliptic_curve_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "P-256",
+				ValidateFunc: validateOneOf("P-256", "P-384", "P-521"),
+			},
+		},
+	}, true)
+}
+
+func getRealmKeystoreEcdsaGeneratedConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+	config.Set("ecdsaEllipticCurveKey", d.Get("elliptic_curve_key").(string))
+	return config, nil
+}
+
+func setRealmKeystoreEcdsaGeneratedConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	d.Set("elliptic_curve_key", config.Get("ecdsaEllipticCurveKey"))
+}
@@ -0,0 +1,46 @@
+// This file provides a Terraform resource for HMAC key providers, which generate the secret used to sign tokens
+// internal to Keycloak (e.g. for actions).
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreHmacGenerated() *schema.Resource {
+	return resourceRealmKeystore(&componentType{
+		providerId: "hmac-generated",
+		getConfig:  getRealmKeystoreHmacGeneratedConfig,
+		setConfig:  setRealmKeystoreHmacGeneratedConfig,
+		schema: map[string]*schema.Schema{
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "HS256",
+				ValidateFunc: validateOneOf("HS256", "HS384", "HS512"),
+			},
+			// In bytes, changing it generates a new secret
+			"secret_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      64,
+				ValidateFunc: validateOneOfInt(16, 24, 32, 64, 128, 256, 512),
+			},
+		},
+	}, false)
+}
+
+func getRealmKeystoreHmacGeneratedConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+
+	config.Set("algorithm", d.Get("algorithm").(string))
+	config.SetInt("secretSize", d.Get("secret_size").(int))
+
+	return config, nil
+}
+
+func setRealmKeystoreHmacGeneratedConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setRealmKeystoreAlgorithm(config, d)
+	d.Set("secret_size", config.GetInt("secretSize"))
+}
@@ -0,0 +1,64 @@
+// This file provides a Terraform resource for key providers loading an RSA key from a Java keystore file on the
+// Keycloak server.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreJavaKeystore() *schema.Resource {
+	return resourceRealmKeystore(&componentType{
+		providerId: "java-keystore",
+		getConfig:  getRealmKeystoreJavaKeystoreConfig,
+		setConfig:  setRealmKeystoreJavaKeystoreConfig,
+		schema: map[string]*schema.Schema{
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RS256",
+				ValidateFunc: validateOneOf(rsaKeyAlgorithms...),
+			},
+			// Path of the keystore file on the Keycloak server
+			"keystore": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"keystore_password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"key_alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"key_password": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}, true)
+}
+
+func getRealmKeystoreJavaKeystoreConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+
+	config.Set("algorithm", d.Get("algorithm").(string))
+	config.Set("keystore", d.Get("keystore").(string))
+	config.Set("keystorePassword", d.Get("keystore_password").(string))
+	config.Set("keyAlias", d.Get("key_alias").(string))
+	config.Set("keyPassword", d.Get("key_password").(string))
+
+	return config, nil
+}
+
+func setRealmKeystoreJavaKeystoreConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setRealmKeystoreAlgorithm(config, d)
+	d.Set("keystore", config.Get("keystore"))
+	setConfigSecret(d, "keystore_password", config.Get("keystorePassword"))
+	d.Set("key_alias", config.Get("keyAlias"))
+	setConfigSecret(d, "key_password", config.Get("keyPassword"))
+}
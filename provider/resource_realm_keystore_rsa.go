@@ -0,0 +1,81 @@
+// This file provides a Terraform resource for RSA key providers holding an existing key, e.g. one issued by an HSM.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreRsa() *schema.Resource {
+	r := resourceRealmKeystore(&componentType{
+		providerId: "rsa",
+		getConfig:  getRealmKeystoreRsaConfig,
+		setConfig:  setRealmKeystoreRsaConfig,
+		schema: map[string]*schema.Schema{
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RS256",
+				ValidateFunc: validateOneOf(rsaKeyAlgorithms...),
+			},
+			// PEM encoded, Keycloak never returns it
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			// PEM encoded, a self-signed certificate is generated if left out. Keycloak lists it as bare base64 (e.g.
+			// after importing), which is the same certificate.
+			"certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return pemBody(old) == pemBody(new)
+				},
+			},
+		},
+	}, true)
+
+	r.CustomizeDiff = customizeRealmKeystoreRsaDiff
+
+	return r
+}
+
+// A new private key comes with a new key, so unless a certificate for it is given, Keycloak generates one. The
+// certificate in the state belongs to the old key and must not be sent along.
+func customizeRealmKeystoreRsaDiff(d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("private_key") {
+		return nil
+	}
+
+	fields := []string{"kid", "public_key"}
+	if !d.HasChange("certificate") {
+		fields = append(fields, "certificate")
+	}
+	for _, field := range fields {
+		if err := d.SetNewComputed(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getRealmKeystoreRsaConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+
+	config.Set("algorithm", d.Get("algorithm").(string))
+	config.Set("privateKey", d.Get("private_key").(string))
+	// Empty when the private key changed without a new certificate, see customizeRealmKeystoreRsaDiff
+	if certificate, present := d.GetOk("certificate"); present {
+		config.Set("certificate", certificate.(string))
+	}
+
+	return config, nil
+}
+
+func setRealmKeystoreRsaConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setRealmKeystoreAlgorithm(config, d)
+	setConfigSecret(d, "private_key", config.Get("privateKey"))
+}
@@ -0,0 +1,46 @@
+// This file provides a Terraform resource for RSA key providers generating their key, along with a self-signed
+// certificate.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmKeystoreRsaGenerated() *schema.Resource {
+	return resourceRealmKeystore(&componentType{
+		providerId: "rsa-generated",
+		getConfig:  getRealmKeystoreRsaGeneratedConfig,
+		setConfig:  setRealmKeystoreRsaGeneratedConfig,
+		schema: map[string]*schema.Schema{
+			"algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RS256",
+				ValidateFunc: validateOneOf(rsaKeyAlgorithms...),
+			},
+			// In bits, changing it generates a new key
+			"key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2048,
+				ValidateFunc: validateOneOfInt(1024, 2048, 4096),
+			},
+		},
+	}, true)
+}
+
+func getRealmKeystoreRsaGeneratedConfig(d *schema.ResourceData) (keycloak.ComponentConfig, error) {
+	config := keycloak.ComponentConfig{}
+
+	config.Set("algorithm", d.Get("algorithm").(string))
+	config.SetInt("keySize", d.Get("key_size").(int))
+
+	return config, nil
+}
+
+func setRealmKeystoreRsaGeneratedConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	setRealmKeystoreAlgorithm(config, d)
+	d.Set("key_size", config.GetInt("keySize"))
+}
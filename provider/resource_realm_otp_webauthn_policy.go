@@ -0,0 +1,299 @@
+// This file provides the OTP and WebAuthn policy blocks of the realm resource. Keycloak keeps these policies as flat
+// realm settings, the blocks group them so they can be left out to keep the realm's current policy.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+var webAuthnSignatureAlgorithms = []string{"ES256", "ES384", "ES512", "RS256", "RS384", "RS512", "RS1"}
+
+func otpPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// totp is time based, hotp counter based
+				"type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "totp",
+					ValidateFunc: validateOneOf("totp", "hotp"),
+				},
+				"algorithm": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "HmacSHA1",
+					ValidateFunc: validateOneOf("HmacSHA1", "HmacSHA256", "HmacSHA512"),
+				},
+				"digits": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      6,
+					ValidateFunc: validateOneOfInt(6, 8),
+				},
+				// Number of periods (or counter values) before and after the current one which are accepted as well
+				"look_ahead_window": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				// In seconds, only used by totp
+				"period": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					ValidateFunc: validateIntAtLeast(1),
+				},
+				// Only used by hotp
+				"initial_counter": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				// The authenticator apps known to support the policy
+				"supported_applications": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func webAuthnPolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"relying_party_entity_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  "keycloak",
+				},
+				// Defaults to the host name Keycloak is accessed with
+				"relying_party_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"signature_algorithms": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateOneOf(webAuthnSignatureAlgorithms...),
+					},
+				},
+				"attestation_conveyance_preference": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "not specified",
+					ValidateFunc: validateOneOf("not specified", "none", "indirect", "direct"),
+				},
+				"authenticator_attachment": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "not specified",
+					ValidateFunc: validateOneOf("not specified", "platform", "cross-platform"),
+				},
+				"require_resident_key": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "not specified",
+					ValidateFunc: validateOneOf("not specified", "Yes", "No"),
+				},
+				"user_verification_requirement": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "not specified",
+					ValidateFunc: validateOneOf("not specified", "required", "preferred", "discouraged"),
+				},
+				// In seconds, 0 means no timeout
+				"create_timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				"avoid_same_authenticator_register": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				// Only authenticators with these AAGUIDs can be registered, any authenticator if empty
+				"acceptable_aaguids": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// Pointers to the realm settings of one of the WebAuthn policies, which only differ by their prefix.
+type webAuthnPolicySettings struct {
+	rpEntityName                    *string
+	signatureAlgorithms             *[]string
+	rpId                            **string
+	attestationConveyancePreference *string
+	authenticatorAttachment         *string
+	requireResidentKey              *string
+	userVerificationRequirement     *string
+	createTimeout                   **int
+	avoidSameAuthenticatorRegister  **bool
+	acceptableAaguids               *[]string
+}
+
+func realmWebAuthnPolicySettings(r *keycloak.Realm, passwordless bool) *webAuthnPolicySettings {
+	if passwordless {
+		return &webAuthnPolicySettings{
+			rpEntityName:                    &r.WebAuthnPolicyPasswordlessRpEntityName,
+			signatureAlgorithms:             &r.WebAuthnPolicyPasswordlessSignatureAlgorithms,
+			rpId:                            &r.WebAuthnPolicyPasswordlessRpId,
+			attestationConveyancePreference: &r.WebAuthnPolicyPasswordlessAttestationConveyancePreference,
+			authenticatorAttachment:         &r.WebAuthnPolicyPasswordlessAuthenticatorAttachment,
+			requireResidentKey:              &r.WebAuthnPolicyPasswordlessRequireResidentKey,
+			userVerificationRequirement:     &r.WebAuthnPolicyPasswordlessUserVerificationRequirement,
+			createTimeout:                   &r.WebAuthnPolicyPasswordlessCreateTimeout,
+			avoidSameAuthenticatorRegister:  &r.WebAuthnPolicyPasswordlessAvoidSameAuthenticatorRegister,
+			acceptableAaguids:               &r.WebAuthnPolicyPasswordlessAcceptableAaguids,
+		}
+	}
+
+	return &webAuthnPolicySettings{
+		rpEntityName:                    &r.WebAuthnPolicyRpEntityName,
+		signatureAlgorithms:             &r.WebAuthnPolicySignatureAlgorithms,
+		rpId:                            &r.WebAuthnPolicyRpId,
+		attestationConveyancePreference: &r.WebAuthnPolicyAttestationConveyancePreference,
+		authenticatorAttachment:         &r.WebAuthnPolicyAuthenticatorAttachment,
+		requireResidentKey:              &r.WebAuthnPolicyRequireResidentKey,
+		userVerificationRequirement:     &r.WebAuthnPolicyUserVerificationRequirement,
+		createTimeout:                   &r.WebAuthnPolicyCreateTimeout,
+		avoidSameAuthenticatorRegister:  &r.WebAuthnPolicyAvoidSameAuthenticatorRegister,
+		acceptableAaguids:               &r.WebAuthnPolicyAcceptableAaguids,
+	}
+}
+
+// Returns the given block of the realm, or nil if it is left out.
+func getRealmPolicyBlock(d *schema.ResourceData, key string) map[string]interface{} {
+	if v, present := d.GetOk(key); present {
+		if blocks := v.([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+			return blocks[0].(map[string]interface{})
+		}
+	}
+	return nil
+}
+
+func interfaceSliceToStrings(untyped []interface{}) []string {
+	values := []string{}
+	for _, v := range untyped {
+		values = append(values, v.(string))
+	}
+	return values
+}
+
+func getRealmOtpPolicy(d *schema.ResourceData, r *keycloak.Realm) {
+	block := getRealmPolicyBlock(d, "otp_policy")
+	if block == nil {
+		return
+	}
+
+	initialCounter := block["initial_counter"].(int)
+	digits := block["digits"].(int)
+	lookAheadWindow := block["look_ahead_window"].(int)
+	period := block["period"].(int)
+
+	r.OtpPolicyType = block["type"].(string)
+	r.OtpPolicyAlgorithm = block["algorithm"].(string)
+	r.OtpPolicyInitialCounter = &initialCounter
+	r.OtpPolicyDigits = &digits
+	r.OtpPolicyLookAheadWindow = &lookAheadWindow
+	r.OtpPolicyPeriod = &period
+}
+
+func setRealmOtpPolicy(r *keycloak.Realm, d *schema.ResourceData) {
+	block := map[string]interface{}{
+		"type":                   r.OtpPolicyType,
+		"algorithm":              r.OtpPolicyAlgorithm,
+		"supported_applications": r.OtpSupportedApplications,
+	}
+	if r.OtpPolicyInitialCounter != nil {
+		block["initial_counter"] = *r.OtpPolicyInitialCounter
+	}
+	if r.OtpPolicyDigits != nil {
+		block["digits"] = *r.OtpPolicyDigits
+	}
+	if r.OtpPolicyLookAheadWindow != nil {
+		block["look_ahead_window"] = *r.OtpPolicyLookAheadWindow
+	}
+	if r.OtpPolicyPeriod != nil {
+		block["period"] = *r.OtpPolicyPeriod
+	}
+
+	d.Set("otp_policy", []interface{}{block})
+}
+
+func getRealmWebAuthnPolicy(d *schema.ResourceData, r *keycloak.Realm, key string, passwordless bool) {
+	block := getRealmPolicyBlock(d, key)
+	if block == nil {
+		return
+	}
+
+	settings := realmWebAuthnPolicySettings(r, passwordless)
+	rpId := block["relying_party_id"].(string)
+	createTimeout := block["create_timeout"].(int)
+	avoidSameAuthenticatorRegister := block["avoid_same_authenticator_register"].(bool)
+
+	*settings.rpEntityName = block["relying_party_entity_name"].(string)
+	*settings.rpId = &rpId
+	*settings.attestationConveyancePreference = block["attestation_conveyance_preference"].(string)
+	*settings.authenticatorAttachment = block["authenticator_attachment"].(string)
+	*settings.requireResidentKey = block["require_resident_key"].(string)
+	*settings.userVerificationRequirement = block["user_verification_requirement"].(string)
+	*settings.createTimeout = &createTimeout
+	*settings.avoidSameAuthenticatorRegister = &avoidSameAuthenticatorRegister
+	*settings.acceptableAaguids = interfaceSliceToStrings(block["acceptable_aaguids"].([]interface{}))
+
+	// Left empty, the algorithms the realm already has are kept
+	if algorithms := interfaceSliceToStrings(block["signature_algorithms"].([]interface{})); len(algorithms) > 0 {
+		*settings.signatureAlgorithms = algorithms
+	}
+}
+
+func setRealmWebAuthnPolicy(r *keycloak.Realm, d *schema.ResourceData, key string, passwordless bool) {
+	settings := realmWebAuthnPolicySettings(r, passwordless)
+
+	block := map[string]interface{}{
+		"relying_party_entity_name":         *settings.rpEntityName,
+		"signature_algorithms":              *settings.signatureAlgorithms,
+		"attestation_conveyance_preference": *settings.attestationConveyancePreference,
+		"authenticator_attachment":          *settings.authenticatorAttachment,
+		"require_resident_key":              *settings.requireResidentKey,
+		"user_verification_requirement":     *settings.userVerificationRequirement,
+		"acceptable_aaguids":                *settings.acceptableAaguids,
+	}
+	if *settings.rpId != nil {
+		block["relying_party_id"] = **settings.rpId
+	}
+	if *settings.createTimeout != nil {
+		block["create_timeout"] = **settings.createTimeout
+	}
+	if *settings.avoidSameAuthenticatorRegister != nil {
+		block["avoid_same_authenticator_register"] = **settings.avoidSameAuthenticatorRegister
+	}
+
+	d.Set(key, []interface{}{block})
+}
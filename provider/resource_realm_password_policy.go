@@ -0,0 +1,241 @@
+// This file provides the password policy block of the realm resource. Keycloak stores the policy as a single string
+// like `length(12) and digits(1) and notUsername`, which is parsed into the block so that diffs don't depend on the
+// order of the policies in the string.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// Policies taking a number, by the ID Keycloak uses for them
+var passwordPolicyIntFields = map[string]string{
+	"length":                        "length",
+	"max_length":                    "maxLength",
+	"digits":                        "digits",
+	"lower_case":                    "lowerCase",
+	"upper_case":                    "upperCase",
+	"special_chars":                 "specialChars",
+	"password_history":              "passwordHistory",
+	"hash_iterations":               "hashIterations",
+	"force_expired_password_change": "forceExpiredPasswordChange",
+}
+
+var passwordPolicyStringFields = map[string]string{
+	"hash_algorithm":     "hashAlgorithm",
+	"regex_pattern":      "regexPattern",
+	"password_blacklist": "passwordBlacklist",
+}
+
+// Policies without a value
+var passwordPolicyBoolFields = map[string]string{
+	"not_username": "notUsername",
+	"not_email":    "notEmail",
+}
+
+func passwordPolicySchema() *schema.Schema {
+	s := map[string]*schema.Schema{
+		// Any other (e.g. custom) policies, by policy ID
+		"other": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+	}
+	// Unset (zero) values leave out the policy
+	for field := range passwordPolicyIntFields {
+		s[field] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validateIntAtLeast(0),
+		}
+	}
+	for field := range passwordPolicyStringFields {
+		s[field] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		}
+	}
+	for field := range passwordPolicyBoolFields {
+		s[field] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
+	}
+
+	// The policy set outside of Terraform is kept if the block is left out, an empty block removes all policies.
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem:     &schema.Resource{Schema: s},
+	}
+}
+
+// Parses a password policy string the way Keycloak does, into the policies' values by policy ID. Policies without a
+// value have an empty value.
+func parsePasswordPolicy(policy string) map[string]string {
+	policies := map[string]string{}
+	if strings.TrimSpace(policy) == "" {
+		return policies
+	}
+
+	for _, p := range strings.Split(policy, " and ") {
+		p = strings.TrimSpace(p)
+		i := strings.Index(p, "(")
+		if i == -1 {
+			policies[p] = ""
+			continue
+		}
+
+		value := strings.TrimSuffix(p[i+1:], ")")
+		// The admin console stores policies without a value like this
+		if value == "undefined" {
+			value = ""
+		}
+		policies[strings.TrimSpace(p[:i])] = value
+	}
+
+	return policies
+}
+
+// Formats the policies into a password policy string, ordered by policy ID.
+func formatPasswordPolicy(policies map[string]string) string {
+	ids := make([]string, 0, len(policies))
+	for id := range policies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	formatted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if policies[id] == "" {
+			formatted = append(formatted, id)
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%s(%s)", id, policies[id]))
+		}
+	}
+
+	return strings.Join(formatted, " and ")
+}
+
+// Returns the policies in the password_policy block, by policy ID.
+func passwordPolicyBlockToPolicies(block map[string]interface{}) map[string]string {
+	policies := map[string]string{}
+
+	for id, value := range block["other"].(map[string]interface{}) {
+		policies[id] = value.(string)
+	}
+	for field, id := range passwordPolicyIntFields {
+		if value := block[field].(int); value != 0 {
+			policies[id] = strconv.Itoa(value)
+		}
+	}
+	for field, id := range passwordPolicyStringFields {
+		if value := block[field].(string); value != "" {
+			policies[id] = value
+		}
+	}
+	for field, id := range passwordPolicyBoolFields {
+		if block[field].(bool) {
+			policies[id] = ""
+		}
+	}
+
+	return policies
+}
+
+func passwordPoliciesToBlock(policies map[string]string) map[string]interface{} {
+	block := map[string]interface{}{}
+	other := map[string]interface{}{}
+
+	known := map[string]bool{}
+	for field, id := range passwordPolicyIntFields {
+		value, _ := strconv.Atoi(policies[id])
+		block[field] = value
+		known[id] = true
+	}
+	for field, id := range passwordPolicyStringFields {
+		block[field] = policies[id]
+		known[id] = true
+	}
+	for field, id := range passwordPolicyBoolFields {
+		_, present := policies[id]
+		block[field] = present
+		known[id] = true
+	}
+
+	for id, value := range policies {
+		if !known[id] {
+			other[id] = value
+		}
+	}
+	block["other"] = other
+
+	return block
+}
+
+// Returns the password policy string to send, or nil to leave the realm's policy as it is.
+func getRealmPasswordPolicy(d *schema.ResourceData) *string {
+	v, present := d.GetOk("password_policy")
+	if !present && !d.HasChange("password_policy") {
+		return nil
+	}
+
+	policies := map[string]string{}
+	if blocks := v.([]interface{}); len(blocks) > 0 && blocks[0] != nil {
+		policies = passwordPolicyBlockToPolicies(blocks[0].(map[string]interface{}))
+	}
+
+	policy := formatPasswordPolicy(policies)
+	return &policy
+}
+
+func setRealmPasswordPolicy(policy *string, d *schema.ResourceData) {
+	if policy == nil {
+		d.Set("password_policy", nil)
+		return
+	}
+
+	d.Set("password_policy", []interface{}{passwordPoliciesToBlock(parsePasswordPolicy(*policy))})
+}
+
+// Checks the policies against the ones the Keycloak server offers, as Keycloak rejects the whole policy otherwise.
+func validateRealmPasswordPolicy(d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("password_policy") {
+		return nil
+	}
+
+	blocks, _ := d.Get("password_policy").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	policies := passwordPolicyBlockToPolicies(blocks[0].(map[string]interface{}))
+	if len(policies) == 0 {
+		return nil
+	}
+
+	info, err := m.(*keycloak.KeycloakClient).GetServerInfo()
+	if err != nil {
+		return err
+	}
+
+	available := map[string]bool{}
+	for _, policy := range info.PasswordPolicies {
+		available[policy.Id] = true
+	}
+
+	for id := range policies {
+		if !available[id] {
+			return fmt.Errorf("Password policy %s is not available on this Keycloak server", id)
+		}
+	}
+
+	return nil
+}
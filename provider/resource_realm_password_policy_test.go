@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePasswordPolicy(t *testing.T) {
+	policies := parsePasswordPolicy("length(12) and notUsername(undefined) and regexPattern(^(a|b)+$) and notEmail")
+	expected := map[string]string{
+		"length":       "12",
+		"notUsername":  "",
+		"regexPattern": "^(a|b)+$",
+		"notEmail":     "",
+	}
+
+	if !reflect.DeepEqual(policies, expected) {
+		t.Fatalf("Parsed %v, expected %v", policies, expected)
+	}
+
+	if len(parsePasswordPolicy("")) != 0 {
+		t.Fatalf("Parsed policies from an empty policy")
+	}
+}
+
+func TestFormatPasswordPolicy(t *testing.T) {
+	policy := formatPasswordPolicy(map[string]string{
+		"length":      "12",
+		"digits":      "1",
+		"notUsername": "",
+	})
+	expected := "digits(1) and length(12) and notUsername"
+
+	if policy != expected {
+		t.Fatalf("Formatted %q, expected %q", policy, expected)
+	}
+
+	if !reflect.DeepEqual(parsePasswordPolicy(policy), map[string]string{"length": "12", "digits": "1", "notUsername": ""}) {
+		t.Fatalf("Formatted policy %q doesn't parse back to the same policies", policy)
+	}
+}
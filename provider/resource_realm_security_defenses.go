@@ -0,0 +1,261 @@
+// This file provides the brute force detection and security defenses blocks of the realm resource.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// Browser security headers, by the key Keycloak uses for them
+var securityHeaderFields = map[string]string{
+	"content_security_policy":             "contentSecurityPolicy",
+	"content_security_policy_report_only": "contentSecurityPolicyReportOnly",
+	"x_content_type_options":              "xContentTypeOptions",
+	"x_frame_options":                     "xFrameOptions",
+	"x_robots_tag":                        "xRobotsTag",
+	"x_xss_protection":                    "xXSSProtection",
+	"strict_transport_security":           "strictTransportSecurity",
+}
+
+// The values Keycloak uses for new realms
+var securityHeaderDefaults = map[string]string{
+	"content_security_policy":             "frame-src 'self'; frame-ancestors 'self'; object-src 'none';",
+	"content_security_policy_report_only": "",
+	"x_content_type_options":              "nosniff",
+	"x_frame_options":                     "SAMEORIGIN",
+	"x_robots_tag":                        "none",
+	"x_xss_protection":                    "1; mode=block",
+	"strict_transport_security":           "max-age=31536000; includeSubDomains",
+}
+
+// Brute force detection is enabled if the block is present, and disabled when it is removed again.
+func bruteForceDetectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				// Disable users until an admin enables them again instead of temporarily
+				"permanent_lockout": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"max_login_failures": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					ValidateFunc: validateIntAtLeast(1),
+				},
+				"wait_increment_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      60,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				"quick_login_check_milli_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1000,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				"minimum_quick_login_wait_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      60,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				"max_failure_wait_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      900,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+				// Time after which the failure count is reset
+				"failure_reset_time_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      43200,
+					ValidateFunc: validateIntAtLeast(0),
+				},
+			},
+		},
+	}
+}
+
+// The flat brute force settings of older versions. Without defaults, so realms using neither these nor the block keep
+// their current settings.
+func deprecatedBruteForceSchema(valueType schema.ValueType, deprecated string) *schema.Schema {
+	return &schema.Schema{
+		Type:          valueType,
+		Optional:      true,
+		Computed:      true,
+		Deprecated:    deprecated,
+		ConflictsWith: []string{"brute_force_detection"},
+	}
+}
+
+// The headers set outside of Terraform are kept if the block is left out.
+func securityDefensesSchema() *schema.Schema {
+	headers := map[string]*schema.Schema{}
+	for field := range securityHeaderFields {
+		headers[field] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  securityHeaderDefaults[field],
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"headers": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Computed: true,
+					MaxItems: 1,
+					Elem:     &schema.Resource{Schema: headers},
+				},
+			},
+		},
+	}
+}
+
+func getRealmBruteForceDetection(d *schema.ResourceData, r *keycloak.Realm) {
+	block := getRealmPolicyBlock(d, "brute_force_detection")
+
+	if block == nil {
+		// Only removing the block disables brute force detection, otherwise the deprecated fields (if any) apply
+		if d.HasChange("brute_force_detection") {
+			protected := false
+			r.BruteForceProtected = &protected
+			return
+		}
+		getDeprecatedRealmBruteForceFields(d, r)
+		return
+	}
+
+	protected := true
+	r.BruteForceProtected = &protected
+
+	permanentLockout := block["permanent_lockout"].(bool)
+	failureFactor := block["max_login_failures"].(int)
+	waitIncrementSeconds := block["wait_increment_seconds"].(int)
+	quickLoginCheckMilliSeconds := block["quick_login_check_milli_seconds"].(int)
+	minimumQuickLoginWaitSeconds := block["minimum_quick_login_wait_seconds"].(int)
+	maxFailureWaitSeconds := block["max_failure_wait_seconds"].(int)
+	maxDeltaTimeSeconds := block["failure_reset_time_seconds"].(int)
+
+	r.PermanentLockout = &permanentLockout
+	r.FailureFactor = &failureFactor
+	r.WaitIncrementSeconds = &waitIncrementSeconds
+	r.QuickLoginCheckMilliSeconds = &quickLoginCheckMilliSeconds
+	r.MinimumQuickLoginWaitSeconds = &minimumQuickLoginWaitSeconds
+	r.MaxFailureWaitSeconds = &maxFailureWaitSeconds
+	r.MaxDeltaTimeSeconds = &maxDeltaTimeSeconds
+}
+
+func getDeprecatedRealmBruteForceFields(d *schema.ResourceData, r *keycloak.Realm) {
+	// GetOkExists, as an explicit false or 0 must be sent as well
+	if v, present := d.GetOkExists("brute_force_protected"); present {
+		protected := v.(bool)
+		r.BruteForceProtected = &protected
+	}
+
+	fields := map[string]**int{
+		"failure_factor":                   &r.FailureFactor,
+		"max_failure_wait_seconds":         &r.MaxFailureWaitSeconds,
+		"minimum_quick_login_wait_seconds": &r.MinimumQuickLoginWaitSeconds,
+		"wait_increment_seconds":           &r.WaitIncrementSeconds,
+		"quick_login_check_milli_seconds":  &r.QuickLoginCheckMilliSeconds,
+		"max_delta_time_seconds":           &r.MaxDeltaTimeSeconds,
+	}
+	for field, value := range fields {
+		if v, present := d.GetOkExists(field); present {
+			i := v.(int)
+			*value = &i
+		}
+	}
+}
+
+func setRealmBruteForceDetection(r *keycloak.Realm, d *schema.ResourceData) {
+	setOptionalBool(d, "brute_force_protected", r.BruteForceProtected)
+	setOptionalInt(d, "failure_factor", r.FailureFactor)
+	setOptionalInt(d, "max_failure_wait_seconds", r.MaxFailureWaitSeconds)
+	setOptionalInt(d, "minimum_quick_login_wait_seconds", r.MinimumQuickLoginWaitSeconds)
+	setOptionalInt(d, "wait_increment_seconds", r.WaitIncrementSeconds)
+	setOptionalInt(d, "quick_login_check_milli_seconds", r.QuickLoginCheckMilliSeconds)
+	setOptionalInt(d, "max_delta_time_seconds", r.MaxDeltaTimeSeconds)
+
+	// The block is only reported once it is managed, so the realms of older configurations don't plan to disable
+	// brute force detection
+	if _, managed := d.GetOk("brute_force_detection"); !managed {
+		return
+	}
+	if r.BruteForceProtected == nil || !*r.BruteForceProtected {
+		d.Set("brute_force_detection", nil)
+		return
+	}
+
+	block := map[string]interface{}{}
+	if r.PermanentLockout != nil {
+		block["permanent_lockout"] = *r.PermanentLockout
+	}
+	if r.FailureFactor != nil {
+		block["max_login_failures"] = *r.FailureFactor
+	}
+	if r.WaitIncrementSeconds != nil {
+		block["wait_increment_seconds"] = *r.WaitIncrementSeconds
+	}
+	if r.QuickLoginCheckMilliSeconds != nil {
+		block["quick_login_check_milli_seconds"] = *r.QuickLoginCheckMilliSeconds
+	}
+	if r.MinimumQuickLoginWaitSeconds != nil {
+		block["minimum_quick_login_wait_seconds"] = *r.MinimumQuickLoginWaitSeconds
+	}
+	if r.MaxFailureWaitSeconds != nil {
+		block["max_failure_wait_seconds"] = *r.MaxFailureWaitSeconds
+	}
+	if r.MaxDeltaTimeSeconds != nil {
+		block["failure_reset_time_seconds"] = *r.MaxDeltaTimeSeconds
+	}
+
+	d.Set("brute_force_detection", []interface{}{block})
+}
+
+func getRealmSecurityDefenses(d *schema.ResourceData, r *keycloak.Realm) {
+	block := getRealmPolicyBlock(d, "security_defenses")
+	if block == nil {
+		return
+	}
+
+	headerBlocks := block["headers"].([]interface{})
+	if len(headerBlocks) == 0 || headerBlocks[0] == nil {
+		return
+	}
+	headerBlock := headerBlocks[0].(map[string]interface{})
+
+	r.BrowserSecurityHeaders = map[string]string{}
+	for field, key := range securityHeaderFields {
+		r.BrowserSecurityHeaders[key] = headerBlock[field].(string)
+	}
+}
+
+func setRealmSecurityDefenses(r *keycloak.Realm, d *schema.ResourceData) {
+	headers := map[string]interface{}{}
+	for field, key := range securityHeaderFields {
+		headers[field] = r.BrowserSecurityHeaders[key]
+	}
+
+	d.Set("security_defenses", []interface{}{
+		map[string]interface{}{
+			"headers": []interface{}{headers},
+		},
+	})
+}
@@ -0,0 +1,167 @@
+// This file provides the SMTP server block of the realm resource. Keycloak never returns the SMTP password, so only a
+// hash of it is kept in the state, which still makes changing the password in the configuration update the realm.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+func smtpServerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"host": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				// Defaults to 25, or 465 with ssl
+				"port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validateIntAtLeast(1),
+				},
+				"from": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"from_display_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reply_to": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"reply_to_display_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"envelope_from": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"starttls": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"ssl": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				// Authentication is only used if the block is present
+				"auth": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"username": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"password": {
+								Type:      schema.TypeString,
+								Required:  true,
+								Sensitive: true,
+								StateFunc: hashSmtpPassword,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func hashSmtpPassword(v interface{}) string {
+	hash := sha256.Sum256([]byte(v.(string)))
+	return hex.EncodeToString(hash[:])
+}
+
+// Returns the SMTP server to send. Without the block an empty server is sent, which removes the realm's SMTP server.
+func getRealmSmtpServer(d *schema.ResourceData) *keycloak.SmtpServer {
+	block := getRealmPolicyBlock(d, "smtp_server")
+	if block == nil {
+		return &keycloak.SmtpServer{}
+	}
+
+	smtp := &keycloak.SmtpServer{
+		Host:               block["host"].(string),
+		From:               block["from"].(string),
+		FromDisplayName:    block["from_display_name"].(string),
+		ReplyTo:            block["reply_to"].(string),
+		ReplyToDisplayName: block["reply_to_display_name"].(string),
+		EnvelopeFrom:       block["envelope_from"].(string),
+		Starttls:           strconv.FormatBool(block["starttls"].(bool)),
+		Ssl:                strconv.FormatBool(block["ssl"].(bool)),
+		Auth:               "false",
+	}
+	if port := block["port"].(int); port != 0 {
+		smtp.Port = strconv.Itoa(port)
+	}
+
+	if auth := block["auth"].([]interface{}); len(auth) > 0 && auth[0] != nil {
+		authBlock := auth[0].(map[string]interface{})
+		smtp.Auth = "true"
+		smtp.User = authBlock["username"].(string)
+
+		// Only a changed password is known in plain text, the state just has its hash
+		if d.HasChange("smtp_server.0.auth.0.password") {
+			smtp.Password = authBlock["password"].(string)
+		} else {
+			smtp.Password = keycloak.ComponentSecretValue
+		}
+	}
+
+	return smtp
+}
+
+func setRealmSmtpServer(smtp *keycloak.SmtpServer, d *schema.ResourceData) {
+	if smtp == nil || smtp.Host == "" {
+		d.Set("smtp_server", nil)
+		return
+	}
+
+	port, _ := strconv.Atoi(smtp.Port)
+	starttls, _ := strconv.ParseBool(smtp.Starttls)
+	ssl, _ := strconv.ParseBool(smtp.Ssl)
+
+	block := map[string]interface{}{
+		"host":                  smtp.Host,
+		"port":                  port,
+		"from":                  smtp.From,
+		"from_display_name":     smtp.FromDisplayName,
+		"reply_to":              smtp.ReplyTo,
+		"reply_to_display_name": smtp.ReplyToDisplayName,
+		"envelope_from":         smtp.EnvelopeFrom,
+		"starttls":              starttls,
+		"ssl":                   ssl,
+	}
+
+	if auth, _ := strconv.ParseBool(smtp.Auth); auth {
+		// Keycloak returns a mask instead of the password, so the hash in the state is kept. Right after a change the
+		// plain text password is read instead, which still needs hashing.
+		password := d.Get("smtp_server.0.auth.0.password").(string)
+		if d.HasChange("smtp_server.0.auth.0.password") {
+			password = hashSmtpPassword(password)
+		}
+
+		block["auth"] = []interface{}{
+			map[string]interface{}{
+				"username": smtp.User,
+				"password": password,
+			},
+		}
+	}
+
+	d.Set("smtp_server", []interface{}{block})
+}
@@ -0,0 +1,225 @@
+// This file provides a Terraform resource for the required actions of a realm (e.g. `UPDATE_PROFILE` or `CONFIGURE_TOTP`),
+// which users have to complete when logging in. Deployed providers which aren't registered with the realm yet are
+// registered when the resource is created.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// The required actions Keycloak registers in every realm, with whether they are enabled by default. Deleting them
+// restores these defaults instead of unregistering them, which would remove them from the realm.
+var builtInRequiredActions = map[string]bool{
+	"CONFIGURE_TOTP":                 true,
+	"TERMS_AND_CONDITIONS":           false,
+	"UPDATE_PASSWORD":                true,
+	"UPDATE_PROFILE":                 true,
+	"VERIFY_EMAIL":                   true,
+	"VERIFY_PROFILE":                 true,
+	"CONFIGURE_RECOVERY_AUTHN_CODES": true,
+	"delete_account":                 false,
+	"delete_credential":              true,
+	"update_user_locale":             true,
+	"webauthn-register":              true,
+	"webauthn-register-passwordless": true,
+}
+
+func resourceRequiredAction() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceRequiredActionRead),
+		Create: schema.CreateFunc(resourceRequiredActionCreate),
+		Update: schema.UpdateFunc(resourceRequiredActionUpdate),
+		Delete: schema.DeleteFunc(resourceRequiredActionDelete),
+
+		// Required actions are importable by alias, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importRequiredActionHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The provider ID of the required action, e.g. `UPDATE_PROFILE`
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Defaults to the name given by the provider
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// Default actions are added to every new user
+			"default_action": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Actions with a lower priority are completed first, defaults to the end of the list
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func importRequiredActionHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, alias, err := splitRealmAlias(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(alias)
+	d.Set("realm", realm)
+
+	err = resourceRequiredActionRead(d, m)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRequiredActionRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	action, err := c.GetRequiredAction(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("alias", action.Alias)
+	d.Set("name", action.Name)
+	d.Set("enabled", action.Enabled)
+	d.Set("default_action", action.DefaultAction)
+	d.Set("priority", action.Priority)
+	d.Set("config", action.Config)
+
+	return nil
+}
+
+func resourceRequiredActionCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	alias := d.Get("alias").(string)
+
+	registered, err := c.ListRequiredActions(realm(d))
+	if err != nil {
+		return err
+	}
+
+	isRegistered := false
+	for _, action := range registered {
+		if action.Alias == alias {
+			isRegistered = true
+		}
+	}
+
+	if !isRegistered {
+		err = registerRequiredAction(c, d, alias)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetId(alias)
+
+	return resourceRequiredActionUpdate(d, m)
+}
+
+func registerRequiredAction(c *keycloak.KeycloakClient, d *schema.ResourceData, alias string) error {
+	unregistered, err := c.ListUnregisteredRequiredActions(realm(d))
+	if err != nil {
+		return err
+	}
+
+	for _, action := range unregistered {
+		if action.ProviderId == alias {
+			if name, present := d.GetOk("name"); present {
+				action.Name = name.(string)
+			}
+			return c.RegisterRequiredAction(action, realm(d))
+		}
+	}
+
+	return fmt.Errorf("There is no required action provider %s in realm %s", alias, realm(d))
+}
+
+func resourceRequiredActionUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	// The name and priority are only known after registering the action, unless they are given
+	action, err := c.GetRequiredAction(d.Id(), realm(d))
+	if err != nil {
+		return err
+	}
+
+	if name, present := d.GetOk("name"); present {
+		action.Name = name.(string)
+	}
+	// GetOk would treat an explicit priority of 0 as not given
+	if priority, present := d.GetOkExists("priority"); present {
+		action.Priority = priority.(int)
+	}
+
+	config := map[string]string{}
+	for k, v := range d.Get("config").(map[string]interface{}) {
+		config[k] = v.(string)
+	}
+
+	action.Enabled = d.Get("enabled").(bool)
+	action.DefaultAction = d.Get("default_action").(bool)
+	action.Config = config
+
+	err = c.UpdateRequiredAction(action, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceRequiredActionRead(d, m)
+}
+
+// Deleting unregisters the required action, which makes it unavailable until it is registered again. Built-in actions
+// are reset to their defaults instead.
+func resourceRequiredActionDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	enabled, builtIn := builtInRequiredActions[d.Id()]
+	if !builtIn {
+		return c.DeleteRequiredAction(d.Id(), realm(d))
+	}
+
+	action, err := c.GetRequiredAction(d.Id(), realm(d))
+	if err != nil {
+		// The realm is already gone, and the action with it
+		return nil
+	}
+
+	action.Enabled = enabled
+	action.DefaultAction = false
+	action.Config = map[string]string{}
+
+	return c.UpdateRequiredAction(action, realm(d))
+}
@@ -0,0 +1,386 @@
+// This file provides a Terraform resource for Keycloak clients using the saml protocol.
+// Protocol-specific settings which Keycloak stores as client attributes are exposed as typed fields.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+const (
+	samlAttributeAuthnStatement          = "saml.authnstatement"
+	samlAttributeServerSignature         = "saml.server.signature"
+	samlAttributeAssertionSignature      = "saml.assertion.signature"
+	samlAttributeClientSignature         = "saml.client.signature"
+	samlAttributeForcePostBinding        = "saml.force.post.binding"
+	samlAttributeEncrypt                 = "saml.encrypt"
+	samlAttributeSignatureAlgorithm      = "saml.signature.algorithm"
+	samlAttributeCanonicalization        = "saml_signature_canonicalization_method"
+	samlAttributeNameIdFormat            = "saml_name_id_format"
+	samlAttributeForceNameIdFormat       = "saml_force_name_id_format"
+	samlAttributeSigningCertificate      = "saml.signing.certificate"
+	samlAttributeEncryptionCertificate   = "saml.encryption.certificate"
+	samlAttributeConsumerUrlPost         = "saml_assertion_consumer_url_post"
+	samlAttributeConsumerUrlRedirect     = "saml_assertion_consumer_url_redirect"
+	samlAttributeLogoutUrlPost           = "saml_single_logout_service_url_post"
+	samlAttributeLogoutUrlRedirect       = "saml_single_logout_service_url_redirect"
+	samlAttributeIdpInitiatedSsoUrlName  = "saml_idp_initiated_sso_url_name"
+	samlAttributeIdpInitiatedRelayState  = "saml_idp_initiated_sso_relay_state"
+	samlAttributeKeyInfoExt              = "saml.server.signature.keyinfo.ext"
+	samlAttributeMultivaluedRoles        = "saml.multivalued.roles"
+	samlAttributeOneTimeUseCondition     = "saml.onetimeuse.condition"
+	samlClientProtocol                   = "saml"
+	samlDefaultCanonicalizationMethod    = "EXCLUSIVE"
+	samlDefaultSignatureAlgorithm        = "RSA_SHA256"
+	samlDefaultNameIdFormat              = "username"
+	samlCanonicalizationExclusive        = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	samlCanonicalizationExclusiveComment = "http://www.w3.org/2001/10/xml-exc-c14n#WithComments"
+	samlCanonicalizationInclusive        = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	samlCanonicalizationInclusiveComment = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315#WithComments"
+)
+
+// Keycloak stores the canonicalization method as the algorithm URI, which is unwieldy to type in a config.
+var samlCanonicalizationMethods = map[string]string{
+	"EXCLUSIVE":               samlCanonicalizationExclusive,
+	"EXCLUSIVE_WITH_COMMENTS": samlCanonicalizationExclusiveComment,
+	"INCLUSIVE":               samlCanonicalizationInclusive,
+	"INCLUSIVE_WITH_COMMENTS": samlCanonicalizationInclusiveComment,
+}
+
+func resourceSamlClient() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceSamlClientRead),
+		Create: schema.CreateFunc(resourceSamlClientCreate),
+		Update: schema.UpdateFunc(resourceSamlClientUpdate),
+		Delete: schema.DeleteFunc(resourceClientDelete),
+
+		// Keycloak clients are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importSamlClientHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "master",
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"redirect_uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"web_origins": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"root_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			// Shown as "Master SAML Processing URL" in the admin console
+			"admin_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"base_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"full_scope_allowed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// Client specific flows (by flow ID), overriding the realm's browser and direct grant flow bindings
+			"authentication_flow_binding_overrides": authenticationFlowBindingOverridesSchema(),
+			"include_authn_statement": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"sign_documents": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"sign_assertions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"client_signature_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"force_post_binding": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"encrypt_assertions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"signature_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      samlDefaultSignatureAlgorithm,
+				ValidateFunc: validateOneOf("RSA_SHA1", "RSA_SHA256", "RSA_SHA512", "DSA_SHA1"),
+			},
+			"canonicalization_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      samlDefaultCanonicalizationMethod,
+				ValidateFunc: validateOneOf("EXCLUSIVE", "EXCLUSIVE_WITH_COMMENTS", "INCLUSIVE", "INCLUSIVE_WITH_COMMENTS"),
+			},
+			"name_id_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      samlDefaultNameIdFormat,
+				ValidateFunc: validateOneOf("username", "email", "transient", "persistent"),
+			},
+			"force_name_id_format": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Shown as "Optimize REDIRECT signing key lookup" in the admin console
+			"sign_key_info_extension": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"multivalued_roles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"one_time_use_condition": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Keycloak generates a signing certificate for new SAML clients if none is given.
+			"signing_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"encryption_certificate": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"assertion_consumer_post_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"assertion_consumer_redirect_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"logout_service_post_binding_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"logout_service_redirect_binding_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"idp_initiated_sso_url_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"idp_initiated_sso_relay_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+
+			// Computed fields (i.e. things looked up in Keycloak after client creation)
+			"saml_idp_descriptor_xml": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func importSamlClientHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	resourceSamlClientRead(d, m)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSamlClientRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	client, err := c.GetClient(d.Id(), realm(d))
+	if err != nil {
+		// Nothing was found, so just always recreate (instead of erroring)
+		d.SetId("")
+		return nil
+	}
+
+	samlClientToResourceData(client, d)
+
+	installation, err := c.GetClientInstallationSamlDesc(d.Id(), realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.Set("saml_idp_descriptor_xml", installation)
+
+	return nil
+}
+
+func resourceSamlClientCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	client := resourceDataToSamlClient(d)
+	created, err := apiClient.CreateClient(&client, realm(d))
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceSamlClientRead(d, m)
+}
+
+func resourceSamlClientUpdate(d *schema.ResourceData, m interface{}) error {
+	client := resourceDataToSamlClient(d)
+	apiClient := m.(*keycloak.KeycloakClient)
+	err := apiClient.UpdateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceSamlClientRead(d, m)
+}
+
+func resourceDataToSamlClient(d *schema.ResourceData) keycloak.Client {
+	attributes := map[string]interface{}{
+		samlAttributeAuthnStatement:         fmt.Sprintf("%t", d.Get("include_authn_statement").(bool)),
+		samlAttributeServerSignature:        fmt.Sprintf("%t", d.Get("sign_documents").(bool)),
+		samlAttributeAssertionSignature:     fmt.Sprintf("%t", d.Get("sign_assertions").(bool)),
+		samlAttributeClientSignature:        fmt.Sprintf("%t", d.Get("client_signature_required").(bool)),
+		samlAttributeForcePostBinding:       fmt.Sprintf("%t", d.Get("force_post_binding").(bool)),
+		samlAttributeEncrypt:                fmt.Sprintf("%t", d.Get("encrypt_assertions").(bool)),
+		samlAttributeSignatureAlgorithm:     d.Get("signature_algorithm").(string),
+		samlAttributeCanonicalization:       samlCanonicalizationMethods[d.Get("canonicalization_method").(string)],
+		samlAttributeNameIdFormat:           d.Get("name_id_format").(string),
+		samlAttributeForceNameIdFormat:      fmt.Sprintf("%t", d.Get("force_name_id_format").(bool)),
+		samlAttributeKeyInfoExt:             fmt.Sprintf("%t", d.Get("sign_key_info_extension").(bool)),
+		samlAttributeMultivaluedRoles:       fmt.Sprintf("%t", d.Get("multivalued_roles").(bool)),
+		samlAttributeOneTimeUseCondition:    fmt.Sprintf("%t", d.Get("one_time_use_condition").(bool)),
+		samlAttributeConsumerUrlPost:        d.Get("assertion_consumer_post_url").(string),
+		samlAttributeConsumerUrlRedirect:    d.Get("assertion_consumer_redirect_url").(string),
+		samlAttributeLogoutUrlPost:          d.Get("logout_service_post_binding_url").(string),
+		samlAttributeLogoutUrlRedirect:      d.Get("logout_service_redirect_binding_url").(string),
+		samlAttributeIdpInitiatedSsoUrlName: d.Get("idp_initiated_sso_url_name").(string),
+		samlAttributeIdpInitiatedRelayState: d.Get("idp_initiated_sso_relay_state").(string),
+	}
+
+	// Only send the certificates if they are known, otherwise Keycloak keeps (or generates) its own
+	if v, present := d.GetOk("signing_certificate"); present {
+		attributes[samlAttributeSigningCertificate] = v.(string)
+	}
+	if v, present := d.GetOk("encryption_certificate"); present {
+		attributes[samlAttributeEncryptionCertificate] = v.(string)
+	}
+
+	c := keycloak.Client{
+		ClientId:                           d.Get("client_id").(string),
+		Enabled:                            d.Get("enabled").(bool),
+		RedirectUris:                       getStringSlice(d, "redirect_uris"),
+		Protocol:                           samlClientProtocol,
+		WebOrigins:                         getStringSlice(d, "web_origins"),
+		RootUrl:                            d.Get("root_url").(string),
+		AdminUrl:                           d.Get("admin_url").(string),
+		BaseUrl:                            d.Get("base_url").(string),
+		AuthenticationFlowBindingOverrides: getAuthenticationFlowBindingOverrides(d),
+		FullScopeAllowed:                   d.Get("full_scope_allowed").(bool),
+		Attributes:                         attributes,
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return c
+}
+
+func samlClientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
+	d.Set("client_id", c.ClientId)
+	d.Set("enabled", c.Enabled)
+	d.Set("redirect_uris", c.RedirectUris)
+	d.Set("web_origins", c.WebOrigins)
+	d.Set("root_url", c.RootUrl)
+	d.Set("admin_url", c.AdminUrl)
+	d.Set("base_url", c.BaseUrl)
+	d.Set("full_scope_allowed", c.FullScopeAllowed)
+	setAuthenticationFlowBindingOverrides(c.AuthenticationFlowBindingOverrides, d)
+
+	d.Set("include_authn_statement", getAttributeBool(c.Attributes, samlAttributeAuthnStatement))
+	d.Set("sign_documents", getAttributeBool(c.Attributes, samlAttributeServerSignature))
+	d.Set("sign_assertions", getAttributeBool(c.Attributes, samlAttributeAssertionSignature))
+	d.Set("client_signature_required", getAttributeBool(c.Attributes, samlAttributeClientSignature))
+	d.Set("force_post_binding", getAttributeBool(c.Attributes, samlAttributeForcePostBinding))
+	d.Set("encrypt_assertions", getAttributeBool(c.Attributes, samlAttributeEncrypt))
+	d.Set("signature_algorithm", getAttributeString(c.Attributes, samlAttributeSignatureAlgorithm))
+	d.Set("name_id_format", getAttributeString(c.Attributes, samlAttributeNameIdFormat))
+	d.Set("force_name_id_format", getAttributeBool(c.Attributes, samlAttributeForceNameIdFormat))
+	d.Set("sign_key_info_extension", getAttributeBool(c.Attributes, samlAttributeKeyInfoExt))
+	d.Set("multivalued_roles", getAttributeBool(c.Attributes, samlAttributeMultivaluedRoles))
+	d.Set("one_time_use_condition", getAttributeBool(c.Attributes, samlAttributeOneTimeUseCondition))
+	d.Set("signing_certificate", getAttributeString(c.Attributes, samlAttributeSigningCertificate))
+	d.Set("encryption_certificate", getAttributeString(c.Attributes, samlAttributeEncryptionCertificate))
+	d.Set("assertion_consumer_post_url", getAttributeString(c.Attributes, samlAttributeConsumerUrlPost))
+	d.Set("assertion_consumer_redirect_url", getAttributeString(c.Attributes, samlAttributeConsumerUrlRedirect))
+	d.Set("logout_service_post_binding_url", getAttributeString(c.Attributes, samlAttributeLogoutUrlPost))
+	d.Set("logout_service_redirect_binding_url", getAttributeString(c.Attributes, samlAttributeLogoutUrlRedirect))
+	d.Set("idp_initiated_sso_url_name", getAttributeString(c.Attributes, samlAttributeIdpInitiatedSsoUrlName))
+	d.Set("idp_initiated_sso_relay_state", getAttributeString(c.Attributes, samlAttributeIdpInitiatedRelayState))
+
+	canonicalizationMethod := samlDefaultCanonicalizationMethod
+	for name, uri := range samlCanonicalizationMethods {
+		if uri == getAttributeString(c.Attributes, samlAttributeCanonicalization) {
+			canonicalizationMethod = name
+		}
+	}
+	d.Set("canonicalization_method", canonicalizationMethod)
+}
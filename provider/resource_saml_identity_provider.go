@@ -0,0 +1,268 @@
+// This file provides a Terraform resource for SAML identity providers.
+// The settings can be given directly, or be taken from the identity provider's SAML metadata (parsed by Keycloak).
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// Keycloak stores the NameID policy as the format URN, which is unwieldy to type in a config.
+var samlNameIdPolicyFormats = map[string]string{
+	"persistent":  "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+	"transient":   "urn:oasis:names:tc:SAML:2.0:nameid-format:transient",
+	"email":       "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+	"kerberos":    "urn:oasis:names:tc:SAML:2.0:nameid-format:kerberos",
+	"x509":        "urn:oasis:names:tc:SAML:1.1:nameid-format:X509SubjectName",
+	"windows":     "urn:oasis:names:tc:SAML:1.1:nameid-format:WindowsDomainQualifiedName",
+	"unspecified": "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified",
+}
+
+// Settings which can come from the metadata, by the config key Keycloak uses for them
+var samlIdentityProviderStringFields = map[string]string{
+	"single_sign_on_service_url": "singleSignOnServiceUrl",
+	"single_logout_service_url":  "singleLogoutServiceUrl",
+	"entity_id":                  "entityId",
+	"principal_type":             "principalType",
+	"principal_attribute":        "principalAttribute",
+	"signature_algorithm":        "signatureAlgorithm",
+	"signing_certificate":        "signingCertificate",
+}
+
+var samlIdentityProviderBoolFields = map[string]string{
+	"want_authn_requests_signed": "wantAuthnRequestsSigned",
+	"want_assertions_signed":     "wantAssertionsSigned",
+	"want_assertions_encrypted":  "wantAssertionsEncrypted",
+	"validate_signature":         "validateSignature",
+	"post_binding_response":      "postBindingResponse",
+	"post_binding_authn_request": "postBindingAuthnRequest",
+	"post_binding_logout":        "postBindingLogout",
+	"force_authn":                "forceAuthn",
+	"backchannel_supported":      "backchannelSupported",
+}
+
+func resourceSamlIdentityProvider() *schema.Resource {
+	s := map[string]*schema.Schema{
+		// The metadata is only parsed when creating the identity provider or when it changes, settings given
+		// explicitly take precedence over the ones from the metadata.
+		"metadata_xml": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"metadata_url"},
+		},
+		"metadata_url": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"metadata_xml"},
+		},
+		"name_id_policy_format": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateOneOf("persistent", "transient", "email", "kerberos", "x509", "windows", "unspecified"),
+		},
+		"principal_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateOneOf("SUBJECT", "ATTRIBUTE", "FRIENDLY_ATTRIBUTE"),
+		},
+		"signature_algorithm": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validateOneOf("RSA_SHA1", "RSA_SHA256", "RSA_SHA512", "DSA_SHA1"),
+		},
+		// The settings taken from the metadata when it was last imported, which tells them apart from the settings
+		// given explicitly once the metadata changes
+		"metadata_config": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+
+	// Everything the metadata may contain is optional and computed, so it doesn't show up as a diff when only given
+	// through the metadata.
+	for field := range samlIdentityProviderStringFields {
+		if _, present := s[field]; !present {
+			s[field] = &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			}
+		}
+	}
+	for field := range samlIdentityProviderBoolFields {
+		s[field] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+		}
+	}
+
+	return resourceIdentityProvider(&identityProviderType{
+		providerId:    "saml",
+		getConfig:     getSamlIdentityProviderConfig,
+		setConfig:     setSamlIdentityProviderConfig,
+		customizeDiff: customizeSamlIdentityProviderDiff,
+		schema:        s,
+	})
+}
+
+func customizeSamlIdentityProviderDiff(d *schema.ResourceDiff, m interface{}) error {
+	err := validateSamlIdentityProviderMetadata(d, m)
+	if err != nil {
+		return err
+	}
+
+	if d.Id() == "" || !(d.HasChange("metadata_xml") || d.HasChange("metadata_url")) {
+		return nil
+	}
+
+	// The settings from the old metadata are replaced by the ones from the new metadata
+	err = d.SetNewComputed("metadata_config")
+	if err != nil {
+		return err
+	}
+	for field := range samlIdentityProviderMetadataFields(d) {
+		err = d.SetNewComputed(field)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Without metadata, the single sign-on URL has to be given explicitly.
+func validateSamlIdentityProviderMetadata(d *schema.ResourceDiff, m interface{}) error {
+	for _, field := range []string{"metadata_xml", "metadata_url", "single_sign_on_service_url"} {
+		if !d.NewValueKnown(field) {
+			return nil
+		}
+	}
+
+	if d.Get("metadata_xml").(string) == "" && d.Get("metadata_url").(string) == "" &&
+		d.Get("single_sign_on_service_url").(string) == "" {
+		return fmt.Errorf("single_sign_on_service_url must be set when neither metadata_xml nor metadata_url is given")
+	}
+
+	return nil
+}
+
+// Returns the config values of all settings which can come from the metadata, by their field.
+func samlIdentityProviderConfigValues(get func(key string) interface{}) map[string]string {
+	values := map[string]string{}
+	for field := range samlIdentityProviderStringFields {
+		values[field] = get(field).(string)
+	}
+	for field := range samlIdentityProviderBoolFields {
+		values[field] = fmt.Sprintf("%t", get(field).(bool))
+	}
+	values["name_id_policy_format"] = samlNameIdPolicyFormats[get("name_id_policy_format").(string)]
+	return values
+}
+
+func samlIdentityProviderConfigKey(field string) string {
+	if key, present := samlIdentityProviderStringFields[field]; present {
+		return key
+	}
+	if key, present := samlIdentityProviderBoolFields[field]; present {
+		return key
+	}
+	return "nameIDPolicyFormat"
+}
+
+// Returns the settings of an existing identity provider which were taken from the metadata, i.e. which weren't
+// changed in the config and still hold the value imported from the metadata.
+func samlIdentityProviderMetadataFields(d *schema.ResourceDiff) map[string]bool {
+	previous, _ := d.GetChange("metadata_config")
+	imported := previous.(map[string]interface{})
+
+	fields := map[string]bool{}
+	for field, value := range samlIdentityProviderConfigValues(d.Get) {
+		if d.HasChange(field) {
+			continue
+		}
+		if v, present := imported[samlIdentityProviderConfigKey(field)]; present && v.(string) == value {
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
+// The metadata is only imported when it changed, otherwise the current settings are sent as they are. The settings
+// taken from the metadata are the ones which aren't known yet: not given when creating, or marked by
+// customizeSamlIdentityProviderDiff when updating.
+func getSamlIdentityProviderConfig(d *schema.ResourceData, c *keycloak.KeycloakClient) (map[string]string, error) {
+	values := samlIdentityProviderConfigValues(d.Get)
+	previous := samlIdentityProviderConfigValues(func(key string) interface{} {
+		old, _ := d.GetChange(key)
+		return old
+	})
+
+	config := map[string]string{}
+	fromMetadata := map[string]bool{}
+	for field, value := range values {
+		// GetOk would treat an explicit false as not given
+		if _, present := d.GetOkExists(field); !present {
+			fromMetadata[field] = true
+			value = previous[field]
+		}
+		config[samlIdentityProviderConfigKey(field)] = value
+	}
+
+	if !d.IsNewResource() && !(d.HasChange("metadata_xml") || d.HasChange("metadata_url")) {
+		return config, nil
+	}
+
+	metadata, err := importSamlIdentityProviderMetadata(d, c)
+	if err != nil {
+		return nil, err
+	}
+
+	imported := map[string]string{}
+	for field := range values {
+		key := samlIdentityProviderConfigKey(field)
+		if value, present := metadata[key]; present {
+			imported[key] = value
+			if fromMetadata[field] {
+				config[key] = value
+			}
+		}
+	}
+	d.Set("metadata_config", imported)
+
+	return config, nil
+}
+
+// Returns the config parsed from the metadata, or an empty config if there is no metadata.
+func importSamlIdentityProviderMetadata(d *schema.ResourceData, c *keycloak.KeycloakClient) (map[string]string, error) {
+	if v, present := d.GetOk("metadata_xml"); present {
+		return c.ImportIdentityProviderConfigFromFile(realm(d), "saml", []byte(v.(string)))
+	}
+
+	if v, present := d.GetOk("metadata_url"); present {
+		return c.ImportIdentityProviderConfigFromUrl(realm(d), "saml", v.(string))
+	}
+
+	return map[string]string{}, nil
+}
+
+func setSamlIdentityProviderConfig(config map[string]string, d *schema.ResourceData) {
+	for field, key := range samlIdentityProviderStringFields {
+		d.Set(field, config[key])
+	}
+	for field, key := range samlIdentityProviderBoolFields {
+		d.Set(field, config[key] == "true")
+	}
+
+	for name, format := range samlNameIdPolicyFormats {
+		if format == config["nameIDPolicyFormat"] {
+			d.Set("name_id_policy_format", name)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// This file provides Terraform resources for the social identity providers built into Keycloak. Their endpoints are
+// fixed, so besides the client credentials there are only a few provider specific settings.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+// A provider specific setting, stored under the given key in the identity provider config.
+type socialIdentityProviderField struct {
+	key    string
+	schema *schema.Schema
+}
+
+func resourceGoogleIdentityProvider() *schema.Resource {
+	return resourceSocialIdentityProvider("google", map[string]socialIdentityProviderField{
+		// Restricts logins to accounts of the given G Suite domain
+		"hosted_domain": {
+			key:    "hostedDomain",
+			schema: &schema.Schema{Type: schema.TypeString, Optional: true},
+		},
+		"use_user_ip_param": {
+			key:    "userIp",
+			schema: &schema.Schema{Type: schema.TypeBool, Optional: true, Default: false},
+		},
+		"request_refresh_token": {
+			key:    "offlineAccess",
+			schema: &schema.Schema{Type: schema.TypeBool, Optional: true, Default: false},
+		},
+	}, nil)
+}
+
+func resourceGithubIdentityProvider() *schema.Resource {
+	return resourceSocialIdentityProvider("github", map[string]socialIdentityProviderField{
+		// Only needed for GitHub Enterprise, e.g. `https://github.example.com` and `https://github.example.com/api/v3`
+		"base_url": {
+			key:    "baseUrl",
+			schema: &schema.Schema{Type: schema.TypeString, Optional: true},
+		},
+		"api_url": {
+			key:    "apiUrl",
+			schema: &schema.Schema{Type: schema.TypeString, Optional: true},
+		},
+	}, validateGithubIdentityProvider)
+}
+
+func resourceMicrosoftIdentityProvider() *schema.Resource {
+	return resourceSocialIdentityProvider("microsoft", map[string]socialIdentityProviderField{
+		// Restricts logins to a single Azure AD tenant, all tenants are allowed if unset
+		"tenant_id": {
+			key:    "tenantId",
+			schema: &schema.Schema{Type: schema.TypeString, Optional: true},
+		},
+	}, nil)
+}
+
+func resourceGitlabIdentityProvider() *schema.Resource {
+	return resourceSocialIdentityProvider("gitlab", map[string]socialIdentityProviderField{}, nil)
+}
+
+// GitHub Enterprise needs both URLs, setting only one of them would mix up github.com and the enterprise instance.
+func validateGithubIdentityProvider(d *schema.ResourceDiff, _ interface{}) error {
+	if (d.Get("base_url").(string) == "") != (d.Get("api_url").(string) == "") {
+		return fmt.Errorf("base_url and api_url must be set together")
+	}
+	return nil
+}
+
+func resourceSocialIdentityProvider(providerId string, fields map[string]socialIdentityProviderField, customizeDiff schema.CustomizeDiffFunc) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"client_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"client_secret": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		// Leaving this empty uses the scopes Keycloak requests by default for the provider
+		"default_scopes": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+	for field, f := range fields {
+		s[field] = f.schema
+	}
+
+	return resourceIdentityProvider(&identityProviderType{
+		providerId:    providerId,
+		schema:        s,
+		customizeDiff: customizeDiff,
+		getConfig: func(d *schema.ResourceData, _ *keycloak.KeycloakClient) (map[string]string, error) {
+			config := map[string]string{
+				"clientId":     d.Get("client_id").(string),
+				"clientSecret": d.Get("client_secret").(string),
+				"defaultScope": d.Get("default_scopes").(string),
+			}
+			for field, f := range fields {
+				config[f.key] = fmt.Sprintf("%v", d.Get(field))
+			}
+			return config, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("client_id", config["clientId"])
+			setConfigSecret(d, "client_secret", config["clientSecret"])
+			d.Set("default_scopes", config["defaultScope"])
+			for field, f := range fields {
+				if f.schema.Type == schema.TypeBool {
+					b, _ := strconv.ParseBool(config[f.key])
+					d.Set(field, b)
+				} else {
+					d.Set(field, config[f.key])
+				}
+			}
+		},
+	})
+}
@@ -0,0 +1,56 @@
+// This file provides the parts shared by the user federation resources. User federation providers are user storage
+// components of the realm, which all share a few settings controlling their order and caching.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+const userStorageProviderType = "org.keycloak.storage.UserStorageProvider"
+
+// These have their own fields in every user federation resource
+var userFederationConfigKeys = []string{"enabled", "priority", "cachePolicy"}
+
+// Returns the given schema merged with the settings shared by all user federation providers.
+func userFederationSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	federation := map[string]*schema.Schema{
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		// Providers with a lower priority are queried first
+		"priority": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		"cache_policy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "DEFAULT",
+			ValidateFunc: validateOneOf("DEFAULT", "EVICT_DAILY", "EVICT_WEEKLY", "MAX_LIFESPAN", "NO_CACHE"),
+		},
+	}
+	for k, v := range s {
+		federation[k] = v
+	}
+	return federation
+}
+
+// Returns a config holding the shared settings, to which the provider specific settings are added.
+func getUserFederationConfig(d *schema.ResourceData) keycloak.ComponentConfig {
+	config := keycloak.ComponentConfig{}
+	config.SetBool("enabled", d.Get("enabled").(bool))
+	config.SetInt("priority", d.Get("priority").(int))
+	config.Set("cachePolicy", d.Get("cache_policy").(string))
+	return config
+}
+
+func setUserFederationConfig(config keycloak.ComponentConfig, d *schema.ResourceData) {
+	d.Set("enabled", config.GetBool("enabled"))
+	d.Set("priority", config.GetInt("priority"))
+	d.Set("cache_policy", config.Get("cachePolicy"))
+}
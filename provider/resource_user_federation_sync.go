@@ -0,0 +1,147 @@
+// This file provides a Terraform resource synchronizing the users of a user federation provider (e.g. LDAP) and,
+// optionally, the data of some of its mappers. The synchronization runs when the resource is created, so changing any
+// of its settings (or the values in `triggers`) runs it again.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/lordbyron/terraform-provider-keycloak/keycloak"
+)
+
+var userFederationSyncActions = map[string]string{
+	"full":    "triggerFullSync",
+	"changed": "triggerChangedUsersSync",
+}
+
+func resourceUserFederationSync() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceUserFederationSyncRead),
+		Create: schema.CreateFunc(resourceUserFederationSyncCreate),
+		Delete: schema.DeleteFunc(resourceUserFederationSyncDelete),
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_federation_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// `changed` only synchronizes the users changed since the last synchronization
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "full",
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("full", "changed"),
+			},
+			// Mappers whose data (e.g. groups or roles) are synchronized after the users
+			"mapper_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"mapper_sync_direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "fedToKeycloak",
+				ForceNew:     true,
+				ValidateFunc: validateOneOf("fedToKeycloak", "keycloakToFed"),
+			},
+			// Arbitrary values, the synchronization runs again whenever any of them change.
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			// Fails the apply if any user (or mapper entry) failed to synchronize
+			"fail_on_error": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
+
+			// Computed fields, the counts include the mappers' results
+			"added": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"updated": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"removed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"failed": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// The result of a synchronization can't be looked up later, so everything stays as it was after the create.
+func resourceUserFederationSyncRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+func resourceUserFederationSyncCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+	federationId := d.Get("user_federation_id").(string)
+
+	result, err := c.SyncUserStorage(federationId, realm(d), userFederationSyncActions[d.Get("action").(string)])
+	if err != nil {
+		return err
+	}
+
+	added, updated, removed, failed := result.Added, result.Updated, result.Removed, result.Failed
+	status := result.Status
+
+	for _, mapperId := range getStringSlice(d, "mapper_ids") {
+		mapperResult, err := c.SyncUserStorageMapper(mapperId, federationId, realm(d), d.Get("mapper_sync_direction").(string))
+		if err != nil {
+			return err
+		}
+
+		added += mapperResult.Added
+		updated += mapperResult.Updated
+		removed += mapperResult.Removed
+		failed += mapperResult.Failed
+		if mapperResult.Status != "" {
+			status = fmt.Sprintf("%s; %s", status, mapperResult.Status)
+		}
+	}
+
+	if failed > 0 && d.Get("fail_on_error").(bool) {
+		return fmt.Errorf("Synchronizing user federation %s failed for %d entries: %s", federationId, failed, status)
+	}
+
+	d.SetId(federationId)
+	d.Set("added", added)
+	d.Set("updated", updated)
+	d.Set("removed", removed)
+	d.Set("failed", failed)
+	d.Set("status", status)
+
+	return nil
+}
+
+// There is nothing to undo, a synchronization only exists in the state.
+func resourceUserFederationSyncDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
@@ -0,0 +1,49 @@
+// This file provides a Terraform resource for mappers formatting the username of users logging in through an identity
+// provider, e.g. `${CLAIM.email}` or `${ALIAS}.${ATTRIBUTE.uid}`.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceUsernameTemplateImporterIdentityProviderMapper() *schema.Resource {
+	return resourceTypedIdentityProviderMapper(&identityProviderMapperType{
+		mapperType: func(_ *schema.ResourceData, providerId string) (string, error) {
+			if isOidcIdentityProvider(providerId) {
+				return "oidc-username-idp-mapper", nil
+			}
+			if providerId == "saml" {
+				return "saml-username-idp-mapper", nil
+			}
+			return "", fmt.Errorf("Username template importers can only be used with OpenID Connect or SAML identity providers, not %s", providerId)
+		},
+		schema: map[string]*schema.Schema{
+			"template": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// LOCAL sets the username of the local user, the others set the username stored with the identity link
+			"target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "LOCAL",
+				ValidateFunc: validateOneOf("LOCAL", "BROKER_ID", "BROKER_USERNAME"),
+			},
+		},
+		getConfig: func(d *schema.ResourceData) (map[string]string, error) {
+			return map[string]string{
+				"template": d.Get("template").(string),
+				"target":   d.Get("target").(string),
+			}, nil
+		},
+		setConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("template", config["template"])
+			if target, present := config["target"]; present {
+				d.Set("target", target)
+			}
+		},
+	})
+}
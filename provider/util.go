@@ -3,6 +3,7 @@ package provider
 import (
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"strconv"
 	"strings"
 )
 
@@ -57,6 +58,110 @@ func getStringSlice(d *schema.ResourceData, key string) []string {
 	return stringSlice
 }
 
+func getStringSet(d *schema.ResourceData, key string) []string {
+	var stringSlice []string = []string{}
+	untyped, present := d.GetOk(key)
+
+	if !present {
+		return stringSlice
+	}
+
+	for _, value := range untyped.(*schema.Set).List() {
+		stringSlice = append(stringSlice, value.(string))
+	}
+
+	return stringSlice
+}
+
+// Some settings (e.g. LDAP object classes) are stored by Keycloak as a single comma separated value.
+func splitCommaList(raw string) []string {
+	values := []string{}
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Keycloak stores all client attributes as strings, these helpers convert them to and from the typed values used in
+// the resource schemas.
+func getAttributeString(attributes map[string]interface{}, key string) string {
+	if v, present := attributes[key]; present && v != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return ""
+}
+
+func getAttributeBool(attributes map[string]interface{}, key string) bool {
+	b, _ := strconv.ParseBool(getAttributeString(attributes, key))
+	return b
+}
+
+// Keycloak doesn't set some attributes until they are changed (e.g. for clients created by older versions), for
+// those a missing attribute means the given default.
+func getAttributeBoolDefault(attributes map[string]interface{}, key string, def bool) bool {
+	if getAttributeString(attributes, key) == "" {
+		return def
+	}
+	return getAttributeBool(attributes, key)
+}
+
+func getAttributeInt(attributes map[string]interface{}, key string) int {
+	i, _ := strconv.Atoi(getAttributeString(attributes, key))
+	return i
+}
+
+// An empty string (rather than "0") tells Keycloak to fall back to the realm setting.
+func intAttribute(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.Itoa(i)
+}
+
+// Returns a ValidateFunc accepting only the given string values.
+func validateOneOf(valid ...string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (w []string, err []error) {
+		for _, s := range valid {
+			if v.(string) == s {
+				return
+			}
+		}
+		err = []error{
+			fmt.Errorf("Invalid value for %s. Valid are %s", k, strings.Join(valid, ", ")),
+		}
+		return
+	}
+}
+
+// Returns a ValidateFunc accepting only the given integer values.
+func validateOneOfInt(valid ...int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (w []string, err []error) {
+		for _, i := range valid {
+			if v.(int) == i {
+				return
+			}
+		}
+		err = []error{
+			fmt.Errorf("Invalid value for %s. Valid are %v", k, valid),
+		}
+		return
+	}
+}
+
+// Returns a ValidateFunc accepting only integers greater than or equal to min.
+func validateIntAtLeast(min int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (w []string, err []error) {
+		if v.(int) < min {
+			err = []error{
+				fmt.Errorf("Invalid value for %s. Must be at least %d", k, min),
+			}
+		}
+		return
+	}
+}
+
 // This function is used when importing realm-specific resources. The realm must be specified by the user when
 // importing by using a `${realm}.${resource_id}` syntax.
 func splitRealmId(raw string) (string, string, error) {
@@ -78,3 +183,15 @@ func splitRealmClientId(raw string) (string, string, string, error) {
 
 	return split[0], split[1], split[2], nil
 }
+
+// Resources which are identified by an alias rather than a generated ID (e.g. identity providers) are imported using a
+// `${realm}/${alias}` syntax, as aliases may contain dots.
+func splitRealmAlias(raw string) (string, string, error) {
+	split := strings.SplitN(raw, "/", 2)
+
+	if len(split) != 2 || split[0] == "" || split[1] == "" {
+		return "", "", fmt.Errorf("Import ID must be specified as '${realm}/${alias}'")
+	}
+
+	return split[0], split[1], nil
+}